@@ -1,8 +1,10 @@
 package main
 
 import (
+    "bufio"
     "encoding/json"
     "flag"
+    "fmt"
     "os"
     "sync"
     "strings"
@@ -21,6 +23,8 @@ func main() {
     concurrency := flag.Int("c", 1000, "Concurrency level")
     timeout := flag.Int("t", 2000, "Timeout in ms")
     portsFlag := flag.String("ports", "", "Comma-separated list of ports or ranges (e.g. 80,443,1000-2000)")
+    ndjson := flag.Bool("ndjson", false, "Stream one JSON result per line as it's found instead of one buffered array")
+    resumeFile := flag.String("resume", "", "Checkpoint file of completed ip:port pairs; skips them and appends new ones")
     flag.Parse()
 
     // 1. Stream Decode JSON from Stdin
@@ -31,18 +35,39 @@ func main() {
         return
     }
 
-    // 2. Setup Channels
+    // 2. Load checkpoint of already-completed targets, if resuming
+    alreadyDone := make(map[string]bool)
+    var checkpointFile *os.File
+    if *resumeFile != "" {
+        if existing, err := os.Open(*resumeFile); err == nil {
+            scanner := bufio.NewScanner(existing)
+            for scanner.Scan() {
+                alreadyDone[scanner.Text()] = true
+            }
+            existing.Close()
+        }
+
+        f, err := os.OpenFile(*resumeFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Error opening checkpoint file: %v\n", err)
+        } else {
+            checkpointFile = f
+            defer checkpointFile.Close()
+        }
+    }
+
+    // 3. Setup Channels
     jobs := make(chan worker.Job, len(targets)*10) // buffer for multiple ports per IP
     results := make(chan worker.Result, len(targets)*10)
     var wg sync.WaitGroup
 
-    // 3. Start Workers
+    // 4. Start Workers
     for i := 0; i < *concurrency; i++ {
         wg.Add(1)
         go worker.Worker(jobs, results, &wg, *timeout)
     }
 
-    // 4. Dispatch Jobs
+    // 5. Dispatch Jobs, skipping anything the checkpoint already covers
     var scanPorts []int
     if *portsFlag != "" {
         scanPorts = parsePorts(*portsFlag)
@@ -52,18 +77,43 @@ func main() {
     }
     for _, t := range targets {
         for _, p := range scanPorts {
+            if alreadyDone[checkpointKey(t.IP, p)] {
+                continue
+            }
             jobs <- worker.Job{IP: t.IP, Hostname: t.Hostname, Port: p, Paths: t.Paths}
         }
     }
     close(jobs)
 
-    // 5. Wait for completion
+    // 6. Collect results: stream NDJSON as they arrive, or buffer into
+    // a single array for backwards-compatible output.
+    if *ndjson {
+        encoder := json.NewEncoder(os.Stdout)
+        var collectWg sync.WaitGroup
+        collectWg.Add(1)
+        go func() {
+            defer collectWg.Done()
+            for r := range results {
+                recordCheckpoint(checkpointFile, r.IP, r.Port)
+                if !r.Open {
+                    continue
+                }
+                encoder.Encode(r)
+            }
+        }()
+
+        wg.Wait()
+        close(results)
+        collectWg.Wait()
+        return
+    }
+
     wg.Wait()
     close(results)
 
-    // 6. Aggregate and Output
     finalOutput := []worker.Result{}
     for r := range results {
+        recordCheckpoint(checkpointFile, r.IP, r.Port)
         if r.Open {
             finalOutput = append(finalOutput, r)
         }
@@ -74,6 +124,17 @@ func main() {
     encoder.Encode(finalOutput)
 }
 
+func checkpointKey(ip string, port int) string {
+    return fmt.Sprintf("%s:%d", ip, port)
+}
+
+func recordCheckpoint(f *os.File, ip string, port int) {
+    if f == nil {
+        return
+    }
+    fmt.Fprintln(f, checkpointKey(ip, port))
+}
+
 func parsePorts(portsStr string) []int {
     var ports []int
     parts := strings.Split(portsStr, ",")
@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"hash/fnv"
+	"io"
+	"math/bits"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// FuzzConfig tunes the soft-404/custom-error filtering used by content
+// discovery. Zero values fall back to the defaults below.
+type FuzzConfig struct {
+	LengthTolerancePct float64 // e.g. 0.10 for +/-10%
+	SimHashThreshold   int     // Hamming distance (0-64) above which a body counts as "different" from baseline
+}
+
+var defaultFuzzConfig = FuzzConfig{
+	LengthTolerancePct: 0.10,
+	SimHashThreshold:   3,
+}
+
+// pageBaseline is the fingerprint of a baseURL's soft-404 behavior:
+// the status/length/simhash a request for an almost-certainly
+// non-existent path comes back with.
+type pageBaseline struct {
+	status  int
+	length  int64
+	simhash uint64
+	valid   bool
+}
+
+var (
+	baselineMu    sync.Mutex
+	baselineCache = make(map[string]*pageBaseline)
+)
+
+// getBaseline returns the soft-404 fingerprint for baseURL, computing
+// and caching it on first use so repeated paths against the same host
+// don't re-probe.
+func getBaseline(client *http.Client, baseURL string) *pageBaseline {
+	baselineMu.Lock()
+	if b, ok := baselineCache[baseURL]; ok {
+		baselineMu.Unlock()
+		return b
+	}
+	baselineMu.Unlock()
+
+	b := &pageBaseline{}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(baseURL + "/" + randomToken() + "-" + randomToken())
+		if err != nil {
+			continue
+		}
+		body := readAndClose(resp)
+
+		b.status = resp.StatusCode
+		b.length = int64(len(body))
+		b.simhash = simhash(body)
+		b.valid = true
+	}
+
+	baselineMu.Lock()
+	baselineCache[baseURL] = b
+	baselineMu.Unlock()
+
+	return b
+}
+
+// looksLikeHit reports whether a probed path's response is distinct
+// enough from baseline to be a real finding. Without a usable
+// baseline it falls back to the plain "200 OK is a hit" heuristic.
+func looksLikeHit(b *pageBaseline, cfg FuzzConfig, status int, length int64, body []byte) bool {
+	if b == nil || !b.valid {
+		return status == 200
+	}
+
+	if status != b.status {
+		return true
+	}
+
+	tolerance := cfg.LengthTolerancePct
+	if tolerance <= 0 {
+		tolerance = defaultFuzzConfig.LengthTolerancePct
+	}
+	lo := float64(b.length) * (1 - tolerance)
+	hi := float64(b.length) * (1 + tolerance)
+	if float64(length) < lo || float64(length) > hi {
+		return true
+	}
+
+	threshold := cfg.SimHashThreshold
+	if threshold <= 0 {
+		threshold = defaultFuzzConfig.SimHashThreshold
+	}
+	return hammingDistance(simhash(body), b.simhash) > threshold
+}
+
+func randomToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "fallback-probe-token"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// simhash produces a 64-bit locality-sensitive fingerprint of body
+// from overlapping 3-word shingles, so near-duplicate pages (the same
+// custom error page rendered with a different timestamp/nonce) land
+// close together in Hamming distance.
+func simhash(body []byte) uint64 {
+	words := strings.Fields(string(body))
+	if len(words) == 0 {
+		return 0
+	}
+
+	const shingleSize = 3
+	var votes [64]int
+
+	addVotes := func(tokens []string) {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(tokens, " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				votes[bit]++
+			} else {
+				votes[bit]--
+			}
+		}
+	}
+
+	if len(words) < shingleSize {
+		addVotes(words)
+	} else {
+		for i := 0; i+shingleSize <= len(words); i++ {
+			addVotes(words[i : i+shingleSize])
+		}
+	}
+
+	var result uint64
+	for bit := 0; bit < 64; bit++ {
+		if votes[bit] > 0 {
+			result |= 1 << uint(bit)
+		}
+	}
+	return result
+}
+
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func readAndClose(resp *http.Response) []byte {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return body
+}
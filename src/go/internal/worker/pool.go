@@ -15,6 +15,7 @@ type Job struct {
 	Hostname string
 	Port     int
 	Paths    []string
+	Fuzz     FuzzConfig
 }
 
 type Result struct {
@@ -149,6 +150,15 @@ func Worker(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup, timeoutM
 				// Only fuzz if the main port is responsive
 				if err == nil {
 					fmt.Fprintf(os.Stderr, "[%s:%d] Starting content discovery (%d paths)...\n", job.IP, job.Port, len(interestingPaths))
+
+					// Establish a soft-404 baseline once per baseURL so a
+					// custom error page that answers 200 doesn't get
+					// reported as a hit for every single path.
+					var base *pageBaseline
+					if len(interestingPaths) > 0 {
+						base = getBaseline(client, baseURL)
+					}
+
 					for i, path := range interestingPaths {
 						// Report progress every 10 paths
 						if i > 0 && i%10 == 0 {
@@ -163,12 +173,10 @@ func Worker(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup, timeoutM
 						targetURL := baseURL + path
 						resp, err := client.Get(targetURL)
 						if err == nil {
-							// Check for 200 OK and ensure it's not a false positive (like a custom 404 page returning 200)
-							// For simplicity in this MVP, we trust 200 OK
-							if resp.StatusCode == 200 {
+							body := readAndClose(resp)
+							if looksLikeHit(base, job.Fuzz, resp.StatusCode, int64(len(body)), body) {
 								vulns = append(vulns, path)
 							}
-							resp.Body.Close()
 						}
 					}
 				}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"skyscan/pkg/cache"
 	"skyscan/pkg/core"
 	"skyscan/pkg/engine"
 	"strings"
@@ -30,16 +31,63 @@ const banner = `
 `
 
 func main() {
+	// `skyscan reproduce <id>` replays a single finding's reproducer
+	// artifact instead of running a scan.
+	if len(os.Args) > 1 && os.Args[1] == "reproduce" {
+		runReproduce(os.Args[2:])
+		return
+	}
+
+	// `skyscan cache prune` deletes expired persistent-cache entries
+	// (see pkg/cache) instead of running a scan.
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		runCache(os.Args[2:])
+		return
+	}
+
 	// Define flags
 	keyword := flag.String("k", "", "Target keyword (company name, project name)")
 	keywordFile := flag.String("kf", "", "File with keywords (one per line)")
 	output := flag.String("o", "", "Output file path (JSON)")
 	threads := flag.Int("t", 50, "Number of concurrent threads")
 	timeout := flag.Int("timeout", 10, "Request timeout in seconds")
-	resolvers := flag.String("r", "", "Custom DNS resolvers (comma-separated)")
+	resolvers := flag.String("r", "", "Custom DNS resolvers (comma-separated; udp://host:port, https://host/dns-query, or quic://host:port, bare host:port is treated as udp)")
 	mutations := flag.String("m", "", "Additional mutations (comma-separated)")
 	jsonOutput := flag.Bool("json", false, "JSON output only (no banner)")
 	silent := flag.Bool("s", false, "Silent mode (minimal output)")
+	ndjson := flag.Bool("ndjson", false, "Stream one JSON result per line to stdout as it's found")
+	resume := flag.String("resume", "", "Checkpoint file to skip already-emitted candidates and resume into")
+	perHostQPS := flag.Float64("qps", 0, "Max requests per second per target host (0 = unlimited)")
+	breakerThreshold := flag.Int("breaker-threshold", 0, "Consecutive 5xx/reset responses before tripping a host's circuit breaker (0 = disabled)")
+	breakerCooldown := flag.Int("breaker-cooldown", 5, "Initial circuit breaker cooldown in seconds (doubles on each repeat trip)")
+	checkpoint := flag.String("checkpoint", "", "Checkpoint journal to skip already-completed targets and resume into")
+	reproducerDir := flag.String("reproducer-dir", "", "Directory to write per-finding reproducer artifacts into (see 'skyscan reproduce')")
+	resultFilter := flag.String("filter", "", `Filter expression results must match, e.g. Category == "storage" and Severity in ["CRITICAL","HIGH"]`)
+	maxPivotDepth := flag.Int("max-pivot-depth", 0, "Rounds of TLS SAN-based pivot re-scanning to perform (0 = disabled)")
+	cacheSize := flag.Int("cache-size", 0, "Max entries in the shared DNS/HTTP response cache (0 = default 10000)")
+	noCache := flag.Bool("no-cache", false, "Disable the shared DNS/HTTP response cache and revalidate every check")
+	noRetry := flag.Bool("no-retry", false, "Disable retrying a connection reset, TLS handshake timeout, or 429/503 with backoff (on by default)")
+	authenticated := flag.Bool("authenticated", false, "Follow up 403s with SigV4-signed requests using resolved AWS credentials (off by default)")
+	awsProfile := flag.String("aws-profile", "", "~/.aws/credentials profile to sign with when -authenticated is set (falls back to env vars, then EC2 IMDS)")
+	s3Endpoint := flag.String("s3-endpoint", "", "Generic S3-compatible endpoint to enumerate path-style (host[:port], e.g. a self-hosted Ceph/MinIO deployment)")
+	bucketLookup := flag.String("bucket-lookup", "", "S3 bucket addressing style: auto (default, tries both and falls back on a wrong-style response), virtual-host, or path")
+	harvestBucketConfig := flag.Bool("harvest-config", false, "Fetch policy/CORS/website/logging/versioning/encryption on every confirmed bucket and score a Severity (off by default, multiplies requests per bucket)")
+	pacerMin := flag.Int("pacer-min", 10, "Adaptive per-host backoff floor in milliseconds (see -pacer-retries)")
+	pacerMax := flag.Int("pacer-max", 2000, "Adaptive per-host backoff ceiling in milliseconds (see -pacer-retries)")
+	pacerRetries := flag.Int("pacer-retries", 0, "Retry 429/503/SlowDown responses this many times with adaptive per-host backoff (0 = disabled, throttling surfaces as a normal error)")
+	endpointsPath := flag.String("endpoints", "", "Path to a JSON endpoint model overriding the embedded AWS/Azure/GCP partition, region, and hostname tables (see pkg/providers.EndpointModel)")
+	partitions := flag.String("partitions", "", "Sovereign/non-standard cloud partitions to also enumerate, comma-separated (e.g. aws-cn,aws-us-gov,azure-china) - off by default")
+	wordlistContainers := flag.String("wordlist-containers", "", "File of Azure container names (one per line) to supplement the built-in list when anonymous list-containers is denied")
+	rateLimit := flag.Int("rate-limit", 0, "Max requests per second per cloud provider (S3/Azure Blob/GCS), independent of -qps's per-host cap (0 = unlimited)")
+	burst := flag.Int("burst", 0, "Per-provider token-bucket burst size for -rate-limit (0 = 1 second's worth of -rate-limit)")
+	outputFormat := flag.String("output-format", "", "Output format for -o: ndjson (default), csv, sarif, or metrics (-o becomes a listen address, e.g. :9090)")
+	cachePath := flag.String("cache-path", "", "Bbolt database to persist DNS/HTTP check results across runs (see 'skyscan cache prune'); empty disables it")
+	cacheTTL := flag.Int("cache-ttl", 0, "Seconds a positive cached result is trusted before re-verification (0 = default 15m; negatives always use their own longer default)")
+	corpus := flag.String("corpus", "", "File of known-valid bucket/storage-account names (one per line) to supplement pkg/permute's bundled corpus for Markov-based generation")
+	osintCensys := flag.String("osint-censys", "", "Censys API key:secret to enable Censys as a passive discovery source (off by default)")
+	osintSecurityTrails := flag.String("osint-securitytrails", "", "SecurityTrails API key to enable it as a passive discovery source (off by default)")
+	osintShodan := flag.String("osint-shodan", "", "Shodan API key to enable it as a passive discovery source (off by default)")
+	osintCertStream := flag.Bool("osint-certstream", false, "Enable the public CertStream firehose as a passive discovery source (off by default, runs until the scan's other phases finish)")
 	help := flag.Bool("h", false, "Show help")
 
 	// Legacy flag compatibility
@@ -64,8 +112,52 @@ func main() {
 
 	// Build configuration
 	config := &core.Config{
-		Threads: *threads,
-		Timeout: *timeout,
+		Threads:              *threads,
+		Timeout:              *timeout,
+		NDJSON:               *ndjson,
+		Resume:               *resume,
+		PerHostQPS:           *perHostQPS,
+		BreakerThreshold:     *breakerThreshold,
+		BreakerCooldown:      *breakerCooldown,
+		Checkpoint:           *checkpoint,
+		ReproducerDir:        *reproducerDir,
+		Filter:               *resultFilter,
+		MaxPivotDepth:        *maxPivotDepth,
+		CacheMaxSize:         *cacheSize,
+		NoCache:              *noCache,
+		NoRetry:              *noRetry,
+		Authenticated:        *authenticated,
+		AWSProfile:           *awsProfile,
+		S3CompatibleEndpoint: *s3Endpoint,
+		BucketLookupType:     *bucketLookup,
+		HarvestBucketConfig:  *harvestBucketConfig,
+		PacerMinSleepMS:      *pacerMin,
+		PacerMaxSleepMS:      *pacerMax,
+		PacerRetries:         *pacerRetries,
+		EndpointsPath:        *endpointsPath,
+		RateLimit:            *rateLimit,
+		Burst:                *burst,
+		OutputFormat:         *outputFormat,
+		CachePath:            *cachePath,
+		CacheTTL:             *cacheTTL,
+	}
+
+	if *partitions != "" {
+		config.Partitions = strings.Split(*partitions, ",")
+	}
+
+	if *osintCensys != "" {
+		key, secret, _ := strings.Cut(*osintCensys, ":")
+		config.OSINT.Censys = core.OSINTSourceConfig{Enabled: true, APIKey: key, APISecret: secret}
+	}
+	if *osintSecurityTrails != "" {
+		config.OSINT.SecurityTrails = core.OSINTSourceConfig{Enabled: true, APIKey: *osintSecurityTrails}
+	}
+	if *osintShodan != "" {
+		config.OSINT.Shodan = core.OSINTSourceConfig{Enabled: true, APIKey: *osintShodan}
+	}
+	if *osintCertStream {
+		config.OSINT.CertStream = core.OSINTSourceConfig{Enabled: true}
 	}
 
 	// Parse resolvers
@@ -86,7 +178,7 @@ func main() {
 		keywords = append(keywords, *keyword)
 	}
 	if *keywordFile != "" {
-		fileKeywords, err := readKeywordsFile(*keywordFile)
+		fileKeywords, err := readLinesFile(*keywordFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading keywords file: %v\n", err)
 			os.Exit(1)
@@ -94,6 +186,24 @@ func main() {
 		keywords = append(keywords, fileKeywords...)
 	}
 
+	if *wordlistContainers != "" {
+		containerWords, err := readLinesFile(*wordlistContainers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading container wordlist: %v\n", err)
+			os.Exit(1)
+		}
+		config.ContainerWordlist = containerWords
+	}
+
+	if *corpus != "" {
+		corpusNames, err := readLinesFile(*corpus)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading corpus file: %v\n", err)
+			os.Exit(1)
+		}
+		config.Corpus = corpusNames
+	}
+
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -109,6 +219,7 @@ func main() {
 
 	// Create scanner
 	scanner := engine.NewFullCloudRecon(config)
+	defer scanner.Close()
 
 	// Run scan for each keyword
 	startTime := time.Now()
@@ -147,12 +258,52 @@ OPTIONS:
     -o string        Output file path (JSON)
     -t int           Number of concurrent threads (default: 50)
     -timeout int     Request timeout in seconds (default: 10)
-    -r string        Custom DNS resolvers (comma-separated)
+    -r string        Custom DNS resolvers (comma-separated; udp://, https://, or quic:// scheme)
     -m string        Additional mutations (comma-separated)
     -json            JSON output only (no banner)
     -s               Silent mode (minimal output)
+    -ndjson          Stream one JSON result per line to stdout as it's found
+    -resume string   Checkpoint file to skip already-emitted candidates and resume into
+    -qps float       Max requests per second per target host (0 = unlimited)
+    -breaker-threshold int    Consecutive 5xx/reset responses before tripping a host's breaker
+    -breaker-cooldown int     Initial breaker cooldown in seconds (default: 5)
+    -checkpoint string        Checkpoint journal to skip already-completed targets and resume into
+    -reproducer-dir string    Directory to write per-finding reproducer artifacts into
+    -filter string   Filter expression results must match (see FILTER SYNTAX below)
+    -max-pivot-depth int      Rounds of TLS SAN-based pivot re-scanning to perform (default: 0, disabled)
+    -cache-size int  Max entries in the shared DNS/HTTP response cache (default: 10000)
+    -no-cache        Disable the shared DNS/HTTP response cache and revalidate every check
+    -no-retry        Disable retrying a connection reset, TLS handshake timeout, or 429/503 with backoff (default: false, retries on)
+    -authenticated   Follow up 403s with SigV4-signed requests using resolved AWS credentials (default: false)
+    -aws-profile string       ~/.aws/credentials profile to sign with when -authenticated is set
+    -s3-endpoint string       Generic S3-compatible endpoint to enumerate path-style (e.g. self-hosted Ceph/MinIO)
+    -bucket-lookup string     S3 addressing style: auto (default), virtual-host, or path
+    -harvest-config  Fetch policy/CORS/website/logging/versioning/encryption on every confirmed bucket and score a Severity (default: false)
+    -pacer-min int            Adaptive per-host backoff floor in milliseconds (default: 10)
+    -pacer-max int            Adaptive per-host backoff ceiling in milliseconds (default: 2000)
+    -pacer-retries int        Retry 429/503/SlowDown responses this many times with adaptive backoff (default: 0, disabled)
+    -endpoints string         Path to a JSON endpoint model overriding the embedded AWS/Azure/GCP partition/region/hostname tables
+    -partitions string        Sovereign/non-standard cloud partitions to also enumerate, comma-separated (e.g. aws-cn,aws-us-gov,azure-china)
+    -wordlist-containers string  File of Azure container names to supplement the built-in list when anonymous list-containers is denied
+    -osint-censys string         Censys API key:secret to enable Censys as a passive discovery source (default: disabled)
+    -osint-securitytrails string SecurityTrails API key to enable it as a passive discovery source (default: disabled)
+    -osint-shodan string         Shodan API key to enable it as a passive discovery source (default: disabled)
+    -osint-certstream            Enable the public CertStream firehose as a passive discovery source (default: false)
+    -rate-limit int  Max requests per second per cloud provider (S3/Azure Blob/GCS/etc.), independent of -qps's per-host cap (default: 0, unlimited)
+    -burst int       Per-provider token-bucket burst size for -rate-limit (default: 0, one second's worth of -rate-limit)
+    -output-format string     Output format for -o: ndjson, csv, sarif, or metrics (default: ndjson)
+    -cache-path string        Bbolt database to persist DNS/HTTP check results across runs (see 'skyscan cache prune')
+    -cache-ttl int   Seconds a positive cached result is trusted before re-verification (default: 0, 15m)
+    -corpus string   File of known-valid bucket/storage-account names to supplement pkg/permute's bundled Markov-model corpus
     -h               Show this help
 
+COMMANDS:
+    skyscan reproduce -dir <reproducer-dir> <id>
+        Replay a single finding's reproducer artifact without re-running the scan.
+
+    skyscan cache prune -cache-path <path>
+        Delete expired entries from a -cache-path persistent cache database.
+
 EXAMPLES:
     # Basic scan
     skyscan -k acme-corp
@@ -169,6 +320,18 @@ EXAMPLES:
     # Custom mutations
     skyscan -k company -m "prod,staging,backup,data"
 
+    # Only report critical/high storage findings
+    skyscan -k company -filter 'Category == "storage" and Severity in ["CRITICAL","HIGH"]'
+
+FILTER SYNTAX:
+    Filters are boolean expressions over a result's JSON fields
+    (Category, Provider, Severity, ...), combined with "and"/"or"/"not":
+
+        Category == "storage"
+        Severity in ["CRITICAL","HIGH"]
+        Provider matches "aws.*"
+        Category == "storage" and not (Severity == "LOW")
+
 DISCOVERED ASSETS:
      Storage      - S3, Azure Blob, GCS, DigitalOcean Spaces
     ️ Compute      - EC2, App Service, Cloud Run, Lambda
@@ -186,20 +349,79 @@ SUPPORTED PROVIDERS:
 `)
 }
 
-func readKeywordsFile(path string) ([]string, error) {
+// runReproduce implements `skyscan reproduce -dir <reproducer-dir> <id>`.
+func runReproduce(args []string) {
+	fs := flag.NewFlagSet("reproduce", flag.ExitOnError)
+	dir := fs.String("dir", "", "Directory containing reproducer artifacts (see -reproducer-dir)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *dir == "" {
+		fmt.Println("USAGE: skyscan reproduce -dir <reproducer-dir> <id>")
+		os.Exit(1)
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(fs.Arg(0), "%d", &id); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid id %q\n", fs.Arg(0))
+		os.Exit(1)
+	}
+
+	if err := engine.Reproduce(*dir, id); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runCache implements `skyscan cache <subcommand>`. "prune" is
+// currently the only one: delete every expired entry from a
+// -cache-path persistent cache database (see pkg/cache).
+func runCache(args []string) {
+	if len(args) == 0 || args[0] != "prune" {
+		fmt.Println("USAGE: skyscan cache prune -cache-path <path>")
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("cache prune", flag.ExitOnError)
+	path := fs.String("cache-path", "", "Bbolt database to prune (see -cache-path)")
+	fs.Parse(args[1:])
+
+	if *path == "" {
+		fmt.Println("USAGE: skyscan cache prune -cache-path <path>")
+		os.Exit(1)
+	}
+
+	store, err := cache.Open(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	removed, err := store.Prune()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[*] Pruned %d expired cache entry(ies)\n", removed)
+}
+
+// readLinesFile reads path as newline-separated entries, trimming
+// whitespace and skipping blank lines and "#"-prefixed comments. Used
+// for both -kf's keyword list and -wordlist-containers' container list.
+func readLinesFile(path string) ([]string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var keywords []string
+	var lines []string
 	for _, line := range strings.Split(string(data), "\n") {
 		line = strings.TrimSpace(line)
 		if line != "" && !strings.HasPrefix(line, "#") {
-			keywords = append(keywords, line)
+			lines = append(lines, line)
 		}
 	}
-	return keywords, nil
+	return lines, nil
 }
 
 func getDefaultMutations() []string {
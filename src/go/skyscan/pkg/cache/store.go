@@ -0,0 +1,163 @@
+// Package cache is a bbolt-backed, on-disk cache shared by
+// net.DNSResolver and net.Client so a scan that's re-run against the
+// same keyword doesn't re-check millions of names a previous run
+// already resolved. It's the persistent counterpart to pkg/net's
+// in-memory responseCache, which is lost the moment the process exits.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// DefaultDNSNegativeTTL, DefaultHTTPNegativeTTL, and
+// DefaultPositiveTTL are the TTLs Store.Set's callers fall back to
+// when Config.CacheTTL is left at its zero value: an NXDOMAIN is
+// trusted far longer than a 404 (a registered-but-unclaimed DNS name
+// is rarer to appear than a transient 404 is to become a 200), and a
+// positive result - found once, but things change - is re-verified on
+// a much shorter horizon than either negative.
+const (
+	DefaultDNSNegativeTTL  = 24 * time.Hour
+	DefaultHTTPNegativeTTL = 1 * time.Hour
+	DefaultPositiveTTL     = 15 * time.Minute
+)
+
+var bucketName = []byte("skyscan")
+
+// Entry is one persisted check result. Status doubles as an HTTP
+// status code for net.Client and a 1/0 exists flag for
+// net.DNSResolver, matching the single {status, size, permissions,
+// checked_at, ttl} value shape every caller writes.
+type Entry struct {
+	Status      int           `json:"status"`
+	Size        int64         `json:"size,omitempty"`
+	Permissions string        `json:"permissions,omitempty"`
+	CheckedAt   time.Time     `json:"checked_at"`
+	TTL         time.Duration `json:"ttl"`
+}
+
+func (e Entry) expired() bool {
+	return time.Since(e.CheckedAt) > e.TTL
+}
+
+// Store is a single bbolt database file holding every cached entry,
+// keyed by Key(provider, target).
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if needed) the bbolt database at path.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache %q: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to init cache %q: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Key returns the storage key for provider's check of target, e.g.
+// Key("dns", "bucket.s3.amazonaws.com") or Key("http", "HEAD http://...").
+func Key(provider, target string) string {
+	return provider + "|" + target
+}
+
+// Get returns key's cached Entry, if present and still within its
+// TTL. A nil Store (caching disabled) always misses. An expired entry
+// is treated as a miss but left in place - Prune is the explicit
+// cleanup path, so a failed prune doesn't silently stop results from
+// being skipped.
+func (s *Store) Get(key string) (Entry, bool) {
+	if s == nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	var found bool
+	s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketName).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil
+		}
+		found = true
+		return nil
+	})
+
+	if !found || entry.expired() {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// Set stores entry under key, overwriting any previous value. A nil
+// Store is a no-op.
+func (s *Store) Set(key string, entry Entry) error {
+	if s == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(key), data)
+	})
+}
+
+// Prune deletes every entry past its TTL and returns how many were
+// removed, for `skyscan cache prune`.
+func (s *Store) Prune() (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	var removed int
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucketName)
+
+		var staleKeys [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			var entry Entry
+			if err := json.Unmarshal(v, &entry); err != nil || entry.expired() {
+				staleKeys = append(staleKeys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range staleKeys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		removed = len(staleKeys)
+		return nil
+	})
+	return removed, err
+}
+
+// Close closes the underlying bbolt database. A nil Store is a no-op.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.db.Close()
+}
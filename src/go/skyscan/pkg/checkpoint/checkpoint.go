@@ -0,0 +1,91 @@
+// Package checkpoint lets a scan resume after being killed by recording
+// how far each provider got through its candidate stream, so a restart
+// doesn't have to re-issue tens of thousands of DNS/HTTP probes.
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Record is one line of the checkpoint journal: the last candidate
+// index a provider is known to have fully emitted for a keyword.
+type Record struct {
+	Keyword  string `json:"keyword"`
+	Provider string `json:"provider"`
+	Index    int    `json:"last_generated_index"`
+}
+
+// Store is an append-only ndjson journal of Records, plus the resume
+// position derived from replaying it on open.
+type Store struct {
+	mu       sync.Mutex
+	file     *os.File
+	progress map[string]int // "keyword|provider" -> last_generated_index
+}
+
+// Open loads an existing checkpoint journal at path (if any) and
+// returns a Store ready to both answer ResumeIndex queries and append
+// new progress to the same file.
+func Open(path string) (*Store, error) {
+	s := &Store{progress: make(map[string]int)}
+
+	if existing, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(existing)
+		for scanner.Scan() {
+			var rec Record
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				continue
+			}
+			key := rec.Keyword + "|" + rec.Provider
+			if rec.Index > s.progress[key] {
+				s.progress[key] = rec.Index
+			}
+		}
+		existing.Close()
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.file = f
+
+	return s, nil
+}
+
+// ResumeIndex returns the first candidate index that has NOT yet been
+// confirmed emitted for (keyword, provider). Indices are 1-based (see
+// Generate), so this is 1 if there's no prior run.
+func (s *Store) ResumeIndex(keyword, provider string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress[keyword+"|"+provider] + 1
+}
+
+// Advance records that index has now been fully emitted for
+// (keyword, provider).
+func (s *Store) Advance(keyword, provider string, index int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyword + "|" + provider
+	if index <= s.progress[key] {
+		return nil
+	}
+	s.progress[key] = index
+
+	line, err := json.Marshal(Record{Keyword: keyword, Provider: provider, Index: index})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	_, err = s.file.Write(line)
+	return err
+}
+
+func (s *Store) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,42 @@
+package checkpoint
+
+import (
+	"context"
+	"skyscan/pkg/core"
+)
+
+// Generate drives prov.Generate but filters its output through store,
+// so callers get exactly the behavior of prov.Generate except that
+// candidates already confirmed emitted by a prior run are skipped and
+// new progress is recorded as it streams past. A nil store disables
+// checkpointing entirely (Generate behaves like prov.Generate).
+func Generate(ctx context.Context, store *Store, keyword string, prov core.Provider, output chan<- string) {
+	if store == nil {
+		prov.Generate(ctx, keyword, output)
+		return
+	}
+
+	resumeFrom := store.ResumeIndex(keyword, prov.Name())
+
+	raw := make(chan string, 256)
+	go func() {
+		defer close(raw)
+		prov.Generate(ctx, keyword, raw)
+	}()
+
+	index := 0
+	for candidate := range raw {
+		index++
+		if index < resumeFrom {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case output <- candidate:
+		}
+
+		_ = store.Advance(keyword, prov.Name(), index)
+	}
+}
@@ -6,6 +6,223 @@ type Config struct {
 	Resolvers []string
 	Threads   int
 	Timeout   int
+
+	// OSINT configures the passive-discovery sources in pkg/providers/osint.
+	OSINT OSINTConfig
+
+	// Resume, if set, is the path to a checkpoint journal (see
+	// pkg/checkpoint) that lets a killed scan pick up where it left off
+	// instead of re-issuing every candidate from scratch.
+	Resume string
+
+	// NDJSON switches result output to one JSON object per line
+	// (flushed as each result is found) instead of a single buffered
+	// JSON array at the end of the scan.
+	NDJSON bool
+
+	// OutputFormat selects engine.FullCloudRecon's OutputSink: "" (default)
+	// and "ndjson" stream one JSON object per line, "csv" streams one
+	// row per result, "sarif" buffers the run and writes one SARIF
+	// 2.1.0 log on completion, and "metrics" serves a Prometheus-style
+	// /metrics endpoint (the output path is a listen address, e.g.
+	// ":9090") with live per-provider finding counts instead of
+	// writing a file at all.
+	OutputFormat string
+
+	// PerHostQPS caps outbound requests per second against any single
+	// eTLD+1 (see pkg/net), so a mutation-heavy run doesn't hammer
+	// *.s3.amazonaws.com into throttling. 0 disables the limiter.
+	PerHostQPS float64
+
+	// BreakerThreshold is the number of consecutive 5xx/connection-reset
+	// responses from a host before pkg/net's circuit breaker opens for
+	// that host. 0 disables the breaker.
+	BreakerThreshold int
+
+	// BreakerCooldown is the initial cooldown, in seconds, a tripped
+	// breaker stays open before allowing another probe; it doubles on
+	// each repeat trip.
+	BreakerCooldown int
+
+	// Checkpoint, if set, is the path to an ndjson journal of every
+	// CloudTarget FullCloudRecon has dispatched (see
+	// pkg/engine/checkpoint.go), letting an interrupted ScanAll skip
+	// already-completed targets on restart.
+	Checkpoint string
+
+	// ReproducerDir, if set, is a directory FullCloudRecon writes one
+	// JSON "reproducer" artifact into per finding (method, URL,
+	// headers, resolved IP, TLS info, timestamp) so a single result can
+	// be replayed later via `skyscan reproduce <id>`.
+	ReproducerDir string
+
+	// Filter is a pkg/filter expression (e.g. `Category == "storage"
+	// and Severity in ["CRITICAL","HIGH"]`) that FullCloudRecon
+	// evaluates against every JSON field of FullCloudResult before
+	// printing/encoding it. An empty Filter matches everything.
+	Filter string
+
+	// MaxPivotDepth caps how many rounds of TLS SAN-based pivoting
+	// FullCloudRecon performs: every SAN observed on a scanned target's
+	// certificate is deduplicated and re-scanned as a new seed keyword,
+	// whose own hits may surface further SANs. 0 disables pivoting.
+	MaxPivotDepth int
+
+	// CacheMaxSize bounds the number of entries pkg/net's shared
+	// DNS/HTTP response cache may hold before evicting the
+	// least-recently-used one. 0 uses a sensible default (10000).
+	CacheMaxSize int
+
+	// NoCache disables pkg/net's DNS/HTTP response cache entirely, so
+	// every check revalidates against the live target instead of
+	// reusing a recent result for a colliding keyword mutation. Also
+	// disables CachePath's persistent cache.
+	NoCache bool
+
+	// NoRetry disables pkg/net.Client's RetryStrategy (see
+	// pkg/net/retry.go), so a connection reset, TLS handshake timeout,
+	// or 429/503 surfaces immediately as a single-attempt failure
+	// instead of being retried with backoff. Retrying is on by default,
+	// since scanning thousands of candidates through NAT/CGNAT or
+	// against a cloud edge that throttles is the common case.
+	NoRetry bool
+
+	// CachePath, if set, is a bbolt database file (see pkg/cache) that
+	// DNSResolver.CheckExists and net.Client.Check consult and update
+	// alongside pkg/net's in-memory response cache, so a later scan
+	// against the same keyword skips names a previous run already
+	// checked instead of starting cold. Empty disables it.
+	CachePath string
+
+	// CacheTTL, in seconds, is how long a positive CachePath result is
+	// trusted before being re-verified (0 uses cache.DefaultPositiveTTL).
+	// Negative results use cache.DefaultDNSNegativeTTL /
+	// DefaultHTTPNegativeTTL regardless of CacheTTL, since an NXDOMAIN
+	// or a 404 is trusted far longer than a hit.
+	CacheTTL int
+
+	// MaxObjects caps the number of objects a storage provider's bucket
+	// listing accumulates across pagination pages before stopping early
+	// (Result.Truncated is set when the cap is hit). 0 uses a sensible
+	// default (1000).
+	MaxObjects int
+
+	// MaxPages caps the number of ListObjectsV2/pageToken pages a
+	// storage provider's bucket listing follows before stopping early,
+	// independent of MaxObjects. 0 uses a sensible default (10).
+	MaxPages int
+
+	// Authenticated enables SigV4-signed follow-up requests against S3
+	// buckets that reject anonymous access (403), using credentials
+	// resolved via AWSProfile, the environment, or EC2 instance
+	// metadata. Off by default so unattended scans stay anonymous.
+	Authenticated bool
+
+	// AWSProfile is the ~/.aws/credentials profile SigV4 signing
+	// should use when Authenticated is set. Falls back to
+	// AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN and
+	// then EC2 instance metadata if empty or not found.
+	AWSProfile string
+
+	// S3CompatibleEndpoint, if set, adds a generic path-style S3
+	// endpoint (host[:port], no scheme) to S3ProviderV2's enumeration -
+	// e.g. a self-hosted Ceph RGW or MinIO deployment that isn't one of
+	// the registered named providers (Wasabi, Backblaze, etc).
+	S3CompatibleEndpoint string
+
+	// PacerMinSleepMS/PacerMaxSleepMS/PacerRetries configure
+	// pkg/net.Client's adaptive per-host backoff (see pkg/net/pacer.go):
+	// on a 429/503/SlowDown response, a host's sleep doubles up to
+	// PacerMaxSleepMS and the request is retried up to PacerRetries
+	// times; on success it decays back toward PacerMinSleepMS. A
+	// non-positive PacerRetries disables the pacer entirely, so
+	// throttling still surfaces as a normal error.
+	PacerMinSleepMS int
+	PacerMaxSleepMS int
+	PacerRetries    int
+
+	// HarvestBucketConfig enables anonymous GETs against a storage
+	// bucket's policy/CORS/website/logging/versioning/encryption/
+	// lifecycle sub-resources (S3ProviderV2.CheckWithACL,
+	// GCSProviderV2.CheckBucketConfig) for every confirmed bucket, and
+	// feeds the findings into Result.Severity. Off by default since it
+	// multiplies the request count per bucket.
+	HarvestBucketConfig bool
+
+	// EndpointsPath, if set, is a path to a JSON document matching
+	// pkg/providers.EndpointModel's schema, overriding the partition
+	// (aws/aws-cn/aws-us-gov/azure/azure-china/gcp) region and hostname
+	// tables embedded in the binary. Lets a user add a region or ship
+	// an internal-only partition without recompiling. Empty uses the
+	// embedded default.
+	EndpointsPath string
+
+	// ContainerWordlist supplements AzureBlobProviderV2's built-in
+	// commonContainers list (see -wordlist-containers) with site-specific
+	// names, used as a fallback when anonymous list-containers is
+	// denied on a storage account.
+	ContainerWordlist []string
+
+	// RateLimit caps requests/sec per cloud provider (S3, Azure Blob,
+	// GCS, ...) in engine.FullCloudRecon's worker loops (see
+	// pkg/net.ProviderLimiter) - distinct from PerHostQPS, which caps
+	// net.Client per eTLD+1 underneath a single provider. On a
+	// sustained run of 429/503 responses a provider's effective rate
+	// is halved, then restored on a sustained healthy run. 0 disables
+	// per-provider limiting.
+	RateLimit int
+
+	// Burst is the per-provider token-bucket burst size ProviderLimiter
+	// allows above RateLimit's steady rate. 0 defaults to RateLimit
+	// (1 second's worth of burst).
+	Burst int
+
+	// Corpus supplements pkg/permute's bundled list of known-valid
+	// bucket/storage-account names with site-specific examples (see
+	// -corpus), both feeding the same order-3 character Markov model
+	// PermuteCore.GenerateAdvanced samples from. More realistic examples
+	// make the model's output look more realistic in turn.
+	Corpus []string
+
+	// BucketLookupType selects which S3 addressing style S3ProviderV2
+	// generates candidates in and how Check reacts when the wrong one
+	// was guessed: "" and "auto" (the default) emit both virtual-host
+	// and path-style URLs, and Check falls back a virtual-host probe to
+	// path-style when the response indicates the endpoint doesn't
+	// support virtual-host addressing (TLS SNI mismatch, or a 400/301
+	// carrying AuthorizationHeaderMalformed/PermanentRedirect) -
+	// mirroring how minio-go negotiates BucketLookupAuto. "virtual-host"
+	// and "path" force one style only, with no fallback, for deployments
+	// where the other style doesn't resolve at all (e.g. no wildcard DNS).
+	BucketLookupType string
+
+	// Partitions names sovereign/non-standard cloud partitions (see
+	// pkg/providers.EndpointModel) that S3ProviderV2, AzureBlobProviderV2,
+	// and gcp.StorageProvider should enumerate in addition to their
+	// always-on commercial partition, e.g. "aws-cn", "aws-us-gov",
+	// "aws-secret", "aws-top-secret", "azure-china", "azure-us-gov",
+	// "azure-germany". Empty means commercial-only, so target counts
+	// don't explode by default. A partition name a given provider
+	// doesn't recognize is ignored.
+	Partitions []string
+}
+
+// OSINTSourceConfig holds per-source credentials and throttling for a
+// single passive-discovery source (crt.sh, Censys, SecurityTrails, ...).
+type OSINTSourceConfig struct {
+	Enabled   bool
+	APIKey    string
+	APISecret string // used by sources with key+secret auth (e.g. Censys)
+	RateLimit int    // max requests per second against this source
+}
+
+// OSINTConfig configures the pkg/providers/osint subsystem.
+type OSINTConfig struct {
+	CrtSh          OSINTSourceConfig
+	Censys         OSINTSourceConfig
+	SecurityTrails OSINTSourceConfig
+	Shodan         OSINTSourceConfig
+	CertStream     OSINTSourceConfig
 }
 
 // Result represents a finding
@@ -17,4 +234,32 @@ type Result struct {
 	Permissions string   `json:"permissions"` // e.g., "READ", "WRITE", "PUBLIC"
 	Files       []string `json:"files,omitempty"`
 	Error       string   `json:"error,omitempty"`
+
+	// Extra holds provider-specific key/value findings that don't fit
+	// Files (e.g. AzureBlobProviderV2 harvesting "container:blob" ->
+	// "size bytes" entries while enumerating multiple containers).
+	Extra map[string]string `json:"extra,omitempty"`
+
+	// Truncated is true when a provider's bucket listing hit
+	// Config.MaxObjects/MaxPages with more pages still available, so
+	// Files under-represents the bucket's true contents.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Severity is set when Config.HarvestBucketConfig is on: a
+	// CRITICAL/HIGH/MEDIUM/LOW score derived from the bucket's policy,
+	// CORS, website, logging, versioning, and encryption configuration,
+	// so a bucket that only exposes metadata (not objects) can still
+	// surface as high-risk.
+	Severity string `json:"severity,omitempty"`
+
+	// Versions holds one description per object version/delete-marker
+	// found by S3ProviderV2.ListVersions, populated automatically when
+	// the bucket is publicly readable and has versioning enabled.
+	Versions []string `json:"versions,omitempty"`
+
+	// DeleteMarkerHidesData is set when ListVersions finds a key whose
+	// current state is a delete marker but an older version of that
+	// key is still listed - the object looks deleted but its data is
+	// still fetchable by VersionId.
+	DeleteMarkerHidesData bool `json:"delete_marker_hides_data,omitempty"`
 }
@@ -0,0 +1,137 @@
+// Package dns implements CNAME-chain-driven discovery of subdomains that
+// point at a third-party CDN/storage backend (CloudFront, S3, Azure CDN,
+// GCS), the precursor to detecting a dangling/takeover-vulnerable
+// resource. It answers a different question than pkg/net's DNSResolver:
+// that package checks whether a bucket-style name exists at all; this
+// one asks what a keyword's conventional subdomains ultimately resolve
+// to.
+package dns
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// candidateSubdomains are the conventional hostnames worth probing for a
+// seed keyword/domain - the ones most often CNAMEd at a CDN or storage
+// backend.
+var candidateSubdomains = []string{"", "assets.", "cdn.", "static.", "www."}
+
+// Candidates returns the hostnames Resolver.Discover tries for seed. A
+// bare keyword ("acme") is turned into a .com guess; anything already
+// containing a dot is treated as a domain and used as-is.
+func Candidates(seed string) []string {
+	domain := seed
+	if !strings.Contains(domain, ".") {
+		domain += ".com"
+	}
+
+	hosts := make([]string, 0, len(candidateSubdomains))
+	for _, sub := range candidateSubdomains {
+		hosts = append(hosts, sub+domain)
+	}
+	return hosts
+}
+
+// EdgeTarget describes one CDN/storage backend a dangling CNAME can
+// point at: Name classifies the core.Result.Provider a Finding against
+// it should be reported as, and Pattern matches the terminal CNAME.
+type EdgeTarget struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// KnownEdgeTargets are the CDN/storage hostnames whose CNAME suffix
+// marks an otherwise-ordinary subdomain as worth probing for a dangling
+// takeover. Order matters: S3_WEBSITE is checked before the broader S3
+// pattern since a website endpoint also ends in ".amazonaws.com".
+var KnownEdgeTargets = []EdgeTarget{
+	{Name: "CLOUDFRONT", Pattern: regexp.MustCompile(`\.cloudfront\.net\.?$`)},
+	{Name: "S3_WEBSITE", Pattern: regexp.MustCompile(`\.s3-website[.-][a-z0-9-]+\.amazonaws\.com\.?$`)},
+	{Name: "S3", Pattern: regexp.MustCompile(`\.s3(-[a-z0-9-]+)?\.amazonaws\.com\.?$`)},
+	{Name: "AZURE_CDN", Pattern: regexp.MustCompile(`\.azureedge\.net\.?$`)},
+	{Name: "GCS", Pattern: regexp.MustCompile(`\.storage\.googleapis\.com\.?$`)},
+}
+
+// MatchEdgeTarget reports which KnownEdgeTargets entry cname's suffix
+// matches, if any.
+func MatchEdgeTarget(cname string) (EdgeTarget, bool) {
+	for _, target := range KnownEdgeTargets {
+		if target.Pattern.MatchString(cname) {
+			return target, true
+		}
+	}
+	return EdgeTarget{}, false
+}
+
+// Finding is one host whose CNAME chain terminates at a known CDN/storage
+// backend.
+type Finding struct {
+	Host   string
+	CNAME  string
+	Target EdgeTarget
+}
+
+// Resolver follows CNAME chains for candidate hostnames using the
+// standard library's resolver - which already follows a chain down to
+// its terminal record internally - rather than pkg/net's hand-rolled
+// UDP/DoH/DoQ transports, since CNAME is the one record type
+// net.Resolver already exposes directly.
+type Resolver struct {
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+// NewResolver creates a Resolver whose lookups are bounded by timeout.
+func NewResolver(timeout time.Duration) *Resolver {
+	return &Resolver{resolver: net.DefaultResolver, timeout: timeout}
+}
+
+// ResolveCNAME returns host's terminal CNAME target, or "" if host has no
+// CNAME (an A/AAAA record directly, or doesn't resolve at all - either
+// way there's nothing to classify).
+func (r *Resolver) ResolveCNAME(ctx context.Context, host string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cname, err := r.resolver.LookupCNAME(ctx, host)
+	if err != nil {
+		return "", err
+	}
+
+	cname = strings.TrimSuffix(cname, ".")
+	if strings.EqualFold(cname, strings.TrimSuffix(host, ".")) {
+		// LookupCNAME returns host itself when there's no CNAME record
+		// to follow.
+		return "", nil
+	}
+	return cname, nil
+}
+
+// Discover resolves every Candidates(seed) hostname and returns the ones
+// whose terminal CNAME matches a KnownEdgeTargets entry. DNS errors
+// (NXDOMAIN, timeout, no CNAME) are skipped rather than failing the
+// whole scan - most candidate subdomains won't exist for a given seed.
+func (r *Resolver) Discover(ctx context.Context, seed string) []Finding {
+	var findings []Finding
+	for _, host := range Candidates(seed) {
+		select {
+		case <-ctx.Done():
+			return findings
+		default:
+		}
+
+		cname, err := r.ResolveCNAME(ctx, host)
+		if err != nil || cname == "" {
+			continue
+		}
+
+		if target, ok := MatchEdgeTarget(cname); ok {
+			findings = append(findings, Finding{Host: host, CNAME: cname, Target: target})
+		}
+	}
+	return findings
+}
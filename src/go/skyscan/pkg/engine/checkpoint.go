@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"skyscan/pkg/providers"
+	"sync"
+)
+
+// targetJournalRecord is one line of FullCloudRecon's checkpoint
+// journal: a CloudTarget's dispatch ID and its current status.
+type targetJournalRecord struct {
+	ID     int64                 `json:"id"`
+	Status string                `json:"status"` // "pending" or "done"
+	Target providers.CloudTarget `json:"target"`
+}
+
+// targetJournal is an append-only ndjson log of every CloudTarget
+// FullCloudRecon has dispatched, so an interrupted ScanAll can skip
+// everything already marked "done" on restart instead of re-checking
+// millions of targets from scratch. An empty path disables it entirely.
+type targetJournal struct {
+	mu      sync.Mutex
+	file    *os.File
+	nextID  int64
+	visited map[string]bool // target.URL -> done
+}
+
+// openTargetJournal replays any existing journal at path to seed the
+// visited set and resume ID numbering, then reopens it for appending.
+// An empty path returns a disabled (no-op) journal.
+func openTargetJournal(path string) (*targetJournal, error) {
+	j := &targetJournal{visited: make(map[string]bool)}
+	if path == "" {
+		return j, nil
+	}
+
+	if existing, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(existing)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var rec targetJournalRecord
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				continue
+			}
+			if rec.ID >= j.nextID {
+				j.nextID = rec.ID + 1
+			}
+			if rec.Status == "done" {
+				j.visited[rec.Target.URL] = true
+			}
+		}
+		existing.Close()
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j.file = f
+
+	return j, nil
+}
+
+// shouldSkip reports whether target was already marked "done" by a
+// prior run.
+func (j *targetJournal) shouldSkip(target providers.CloudTarget) bool {
+	if j == nil {
+		return false
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.visited[target.URL]
+}
+
+// recordPending assigns target the next journal ID and appends a
+// "pending" record, returning the ID so recordDone can close it out.
+func (j *targetJournal) recordPending(target providers.CloudTarget) int64 {
+	if j == nil || j.file == nil {
+		return 0
+	}
+
+	j.mu.Lock()
+	id := j.nextID
+	j.nextID++
+	j.mu.Unlock()
+
+	j.append(targetJournalRecord{ID: id, Status: "pending", Target: target})
+	return id
+}
+
+// recordDone marks target's journal entry complete so a future resume
+// skips it.
+func (j *targetJournal) recordDone(id int64, target providers.CloudTarget) {
+	if j == nil || j.file == nil {
+		return
+	}
+
+	j.mu.Lock()
+	j.visited[target.URL] = true
+	j.mu.Unlock()
+
+	j.append(targetJournalRecord{ID: id, Status: "done", Target: target})
+}
+
+func (j *targetJournal) append(rec targetJournalRecord) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.file.Write(line)
+}
+
+func (j *targetJournal) Close() error {
+	if j == nil || j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"os"
 	"skyscan/pkg/core"
+	"skyscan/pkg/filter"
 	"skyscan/pkg/net"
 	"skyscan/pkg/netmapper"
+	"skyscan/pkg/osint"
 	"skyscan/pkg/permute"
 	"skyscan/pkg/providers"
 	awsprovider "skyscan/pkg/providers/aws"
 	azureprovider "skyscan/pkg/providers/azure"
 	gcpprovider "skyscan/pkg/providers/gcp"
+	"skyscan/pkg/providers/metadata"
+	osintsource "skyscan/pkg/providers/osint"
+	"skyscan/pkg/providers/registry"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,6 +33,12 @@ type FullCloudRecon struct {
 	netMapper   *netmapper.NetMapper
 	permuter    *permute.PermuteCore
 
+	// limiter caps outbound requests per cloud provider (S3, Azure Blob,
+	// GCS, or a CloudService's Provider) independent of httpClient's own
+	// per-host pacing, so -rate-limit/-burst apply across every target
+	// that provider resolves to, not just one host.
+	limiter *net.ProviderLimiter
+
 	// Storage providers
 	s3Provider    *awsprovider.S3ProviderV2
 	azureProvider *azureprovider.AzureBlobProviderV2
@@ -36,6 +47,25 @@ type FullCloudRecon struct {
 	// General cloud asset enumerator
 	cloudEnumerator *providers.CloudAssetEnumerator
 
+	// Dangling CDN/CNAME takeover detection
+	cloudfrontProvider *awsprovider.CloudFrontProvider
+
+	// Container registry manifest probing
+	registryProvider *registry.Provider
+
+	// Cloud instance metadata SSRF/IMDS probing
+	metadataProvider *metadata.Provider
+
+	// osintAggregator fans candidate URLs from the enabled -osint-*
+	// sources (Censys, SecurityTrails, Shodan, CertStream) into
+	// scanOSINT. Sources left disabled in config.OSINT are dropped at
+	// construction, so this is a same-keyword no-op by default.
+	osintAggregator *osintsource.Aggregator
+
+	// pivotSeen deduplicates TLS SANs already expanded into a pivot
+	// scan, across the whole run (including nested pivot rounds).
+	pivotSeen *pivotSeen
+
 	// Stats
 	checked   atomic.Int64
 	found     atomic.Int64
@@ -55,26 +85,76 @@ type FullCloudResult struct {
 	Files       []string `json:"files,omitempty"`
 	Timestamp   string   `json:"timestamp"`
 	Category    string   `json:"category"` // storage, compute, database, api, cdn, etc.
+
+	// DiscoveredVia names the parent host whose TLS certificate SAN led
+	// to this result being scanned as a pivot seed. Empty for results
+	// found directly from the original keyword/mutations.
+	DiscoveredVia string `json:"discovered_via,omitempty"`
 }
 
 // NewFullCloudRecon creates a new comprehensive cloud reconnaissance engine
 func NewFullCloudRecon(config *core.Config) *FullCloudRecon {
-	httpClient := net.NewClient(config.Timeout)
-	dnsResolver := net.NewDNSResolver(config.Resolvers, config.Timeout)
+	httpClient := net.NewClient(config)
+	dnsResolver := net.NewDNSResolver(config)
 	netMapper := netmapper.NewNetMapper()
-	permuter := permute.NewPermuteCore()
+	permuter := permute.NewPermuteCore(config)
 
 	return &FullCloudRecon{
-		config:          config,
-		httpClient:      httpClient,
-		dnsResolver:     dnsResolver,
-		netMapper:       netMapper,
-		permuter:        permuter,
-		s3Provider:      awsprovider.NewS3ProviderV2(httpClient, dnsResolver),
-		azureProvider:   azureprovider.NewAzureBlobProviderV2(httpClient, dnsResolver),
-		gcsProvider:     gcpprovider.NewGCSProviderV2(httpClient, dnsResolver),
-		cloudEnumerator: providers.NewCloudAssetEnumerator(httpClient, dnsResolver),
+		config:             config,
+		httpClient:         httpClient,
+		dnsResolver:        dnsResolver,
+		netMapper:          netMapper,
+		permuter:           permuter,
+		limiter:            net.NewProviderLimiter(float64(config.RateLimit), config.Burst),
+		s3Provider:         awsprovider.NewS3ProviderV2(httpClient, dnsResolver),
+		azureProvider:      azureprovider.NewAzureBlobProviderV2(httpClient, dnsResolver),
+		gcsProvider:        gcpprovider.NewGCSProviderV2(httpClient, dnsResolver),
+		cloudEnumerator:    providers.NewCloudAssetEnumerator(httpClient, dnsResolver),
+		cloudfrontProvider: awsprovider.NewCloudFrontProvider(httpClient),
+		registryProvider:   registry.NewProvider(httpClient),
+		metadataProvider:   metadata.NewProvider(),
+		osintAggregator: osintsource.NewAggregator(config,
+			osintsource.NewCensysSource(),
+			osintsource.NewSecurityTrailsSource(),
+			osintsource.NewShodanSource(),
+			osintsource.NewCertStreamSource(),
+		),
+		pivotSeen: newPivotSeen(),
+	}
+}
+
+// Close closes the persistent caches opened for Config.CachePath, if
+// any (see pkg/cache). Safe to call even when CachePath was never set.
+func (r *FullCloudRecon) Close() error {
+	httpErr := r.httpClient.Close()
+	if dnsErr := r.dnsResolver.Close(); dnsErr != nil {
+		return dnsErr
+	}
+	return httpErr
+}
+
+// seedFromOSINT runs pkg/osint's Seeders against keyword treated as a
+// domain, extracts the subdomain labels they find, and runs each
+// through LearnFromDiscovery the same way a live hit would - so a
+// passively-discovered "acme-prod-2022.acme.com" also yields
+// "acme-prod-2023" as a candidate. Seeding is best-effort: a source
+// that errors or finds nothing simply contributes no candidates.
+func (r *FullCloudRecon) seedFromOSINT(ctx context.Context, keyword string) []string {
+	labels := osint.SeedAll(ctx, keyword,
+		osint.NewCrtShSeeder(),
+		osint.NewPassiveDNSSeeder(),
+		osint.NewDNSRecordSeeder(r.dnsResolver),
+	)
+	if len(labels) > 0 {
+		fmt.Printf("[*] OSINT seeding found %d subdomain label(s) for '%s'\n", len(labels), keyword)
+	}
+
+	var candidates []string
+	for _, label := range labels {
+		candidates = append(candidates, label)
+		candidates = append(candidates, r.permuter.LearnFromDiscovery(label)...)
 	}
+	return candidates
 }
 
 // ScanAll performs comprehensive cloud reconnaissance
@@ -88,10 +168,24 @@ func (r *FullCloudRecon) ScanAll(ctx context.Context, keyword string, outputPath
 	r.azureProvider.Init(r.config)
 	r.gcsProvider.Init(r.config)
 	r.cloudEnumerator.Init(r.config)
-
-	// Generate all mutations
+	r.registryProvider.Init(r.config)
+	r.metadataProvider.Init(r.config)
+	r.cloudfrontProvider.Init(r.config)
+
+	// Generate all mutations, then seed from passive OSINT (CT logs,
+	// passive DNS, live DNS records) and fold any subdomain labels found
+	// through the same pattern-learning LearnFromDiscovery applies to
+	// live hits, so a real "acme-prod-2022" subdomain seeds
+	// "acme-prod-2023" etc. before the first request ever goes out.
+	//
+	// OSINT labels go right after keyword, ahead of the guessed
+	// mutations: scanCloudServices caps keywords at 50 to bound its
+	// keyword x 100+-services fan-out, and a real discovered subdomain
+	// is worth more than a guessed one, so it shouldn't lose that cap's
+	// coin flip to mutations GenerateAdvanced alone already exceeds 50.
 	mutations := r.permuter.GenerateAdvanced(keyword)
-	allKeywords := append([]string{keyword}, mutations...)
+	allKeywords := append([]string{keyword}, r.seedFromOSINT(ctx, keyword)...)
+	allKeywords = append(allKeywords, mutations...)
 
 	fmt.Printf("\n[*]  Full Cloud Reconnaissance Starting\n")
 	fmt.Printf("[*] Target: %s\n", keyword)
@@ -100,81 +194,185 @@ func (r *FullCloudRecon) ScanAll(ctx context.Context, keyword string, outputPath
 	fmt.Printf("[*] Threads: %d\n", r.config.Threads)
 	fmt.Println()
 
-	// Open output file
-	var outputFile *os.File
-	var encoder *json.Encoder
-	if outputPath != "" {
-		var err error
-		outputFile, err = os.Create(outputPath)
-		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
-		}
-		defer outputFile.Close()
-		encoder = json.NewEncoder(outputFile)
+	// sink is where every filtered result is written as it's found,
+	// independent of the console summary below - see pkg/engine/sink.go.
+	sink, err := newOutputSink(outputPath, r.config.OutputFormat)
+	if err != nil {
+		return err
+	}
+	defer sink.Close()
+
+	// Compile the result filter once; an empty Config.Filter matches
+	// everything.
+	resultFilter, err := filter.Parse(r.config.Filter)
+	if err != nil {
+		return fmt.Errorf("invalid filter expression: %w", err)
 	}
 
 	// Collect results in background
+	var stdoutEncoder *json.Encoder
+	if r.config.NDJSON {
+		stdoutEncoder = json.NewEncoder(os.Stdout)
+	}
+
 	var allResults []*FullCloudResult
 	done := make(chan bool)
 	go func() {
 		for result := range results {
-			icon := ""
-			switch result.Category {
-			case "storage":
-				icon = ""
-			case "database":
-				icon = "️"
-			case "compute":
-				icon = "️"
-			case "api":
-				icon = ""
-			case "cdn":
-				icon = ""
-			case "container":
-				icon = ""
-			case "serverless":
-				icon = ""
-			}
+			allResults = append(allResults, result)
+			r.found.Add(1)
 
-			severity := result.Severity
-			if severity == "CRITICAL" {
-				severity = " CRITICAL"
-			} else if severity == "HIGH" {
-				severity = " HIGH"
-			} else if severity == "MEDIUM" {
-				severity = " MEDIUM"
+			if !matchesFilter(resultFilter, result) {
+				continue
 			}
 
-			fmt.Printf("%s [%s] %s - %s/%s (%d)\n",
-				icon, severity, result.URL, result.Provider, result.ServiceType, result.Status)
+			if stdoutEncoder != nil {
+				// NDJSON mode: one flushed JSON object per line, no
+				// decoration, so output stays pipeable.
+				stdoutEncoder.Encode(result)
+			} else {
+				icon := ""
+				switch result.Category {
+				case "storage":
+					icon = ""
+				case "database":
+					icon = "️"
+				case "compute":
+					icon = "️"
+				case "api":
+					icon = ""
+				case "cdn":
+					icon = ""
+				case "container":
+					icon = ""
+				case "serverless":
+					icon = ""
+				}
+
+				severity := result.Severity
+				if severity == "CRITICAL" {
+					severity = " CRITICAL"
+				} else if severity == "HIGH" {
+					severity = " HIGH"
+				} else if severity == "MEDIUM" {
+					severity = " MEDIUM"
+				}
 
-			if encoder != nil {
-				encoder.Encode(result)
+				fmt.Printf("%s [%s] %s - %s/%s (%d)\n",
+					icon, severity, result.URL, result.Provider, result.ServiceType, result.Status)
 			}
 
-			allResults = append(allResults, result)
-			r.found.Add(1)
+			if err := sink.Write(toScanResult(result)); err != nil {
+				fmt.Fprintf(os.Stderr, "[!] failed to write result: %v\n", err)
+			}
 		}
 		done <- true
 	}()
 
 	// ===== PHASE 1: Storage Enumeration =====
-	fmt.Println("[*] Phase 1/4: Storage Enumeration (S3, Azure Blob, GCS)...")
+	fmt.Println("[*] Phase 1/7: Storage Enumeration (S3, Azure Blob, GCS)...")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.scanStorage(ctx, allKeywords, "", results)
+	}()
+
+	// ===== PHASE 2: OSINT Passive Discovery =====
+	// Runs on its own context and WaitGroup, not the main wg: the
+	// CertStream source (when enabled) streams until canceled rather
+	// than completing on its own, so it can't share a barrier with the
+	// other, finite phases without wedging ScanAll's wg.Wait() forever.
+	// It's given until the main phases finish, then canceled.
+	fmt.Println("[*] Phase 2/7: OSINT Passive Discovery (Censys, SecurityTrails, Shodan, CertStream)...")
+	osintCtx, cancelOSINT := context.WithCancel(ctx)
+	var osintWG sync.WaitGroup
+	osintWG.Add(1)
+	go func() {
+		defer osintWG.Done()
+		r.scanOSINT(osintCtx, keyword, "", results)
+	}()
+
+	// ===== PHASE 3: All Cloud Services =====
+	fmt.Println("[*] Phase 3/7: Cloud Services Enumeration (100+ services)...")
+
+	// Checkpoint journal: lets an interrupted scan resume without
+	// re-checking every target that's already been marked "done".
+	journal, err := openTargetJournal(r.config.Checkpoint)
+	if err != nil {
+		fmt.Printf("[!] Failed to open checkpoint journal %s: %v\n", r.config.Checkpoint, err)
+		journal, _ = openTargetJournal("")
+	}
+	defer journal.Close()
+
+	// pivotQueue collects every TLS SAN observed on a live cloud-service
+	// target's certificate, to be expanded into further scan rounds
+	// once the initial phases finish. A dropped SAN (full queue) just
+	// means one fewer pivot seed, not a correctness problem.
+	pivotQueue := make(chan pivotHit, 10000)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.scanCloudServices(ctx, allKeywords, "", results, journal, pivotQueue)
+	}()
+
+	// ===== PHASE 4: Container Registry Probing =====
+	fmt.Println("[*] Phase 4/7: Container Registry Probing (ECR, ACR, GCR, GHCR, Docker Hub)...")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.scanContainerRegistries(ctx, allKeywords, "", results)
+	}()
+
+	// ===== PHASE 5: Cloud Metadata SSRF / IMDS Probing =====
+	fmt.Println("[*] Phase 5/7: Cloud Metadata SSRF/IMDS Probing (AWS, GCP, Azure, DigitalOcean, Alibaba)...")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.scanMetadata(ctx, allKeywords, "", results)
+	}()
+
+	// ===== PHASE 6: Dangling CDN/CNAME Takeover Detection =====
+	fmt.Println("[*] Phase 6/7: Dangling CDN/CNAME Takeover Detection (CloudFront and other edge backends)...")
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		r.scanStorage(ctx, allKeywords, results)
+		r.scanCloudFront(ctx, allKeywords, "", results)
 	}()
 
-	// ===== PHASE 2: All Cloud Services =====
-	fmt.Println("[*] Phase 2/4: Cloud Services Enumeration (100+ services)...")
+	wg.Wait()
+	cancelOSINT()
+	osintWG.Wait()
+	close(pivotQueue)
+
+	// ===== PHASE 7: TLS SAN Pivot Discovery =====
+	if r.config.MaxPivotDepth > 0 {
+		r.expandPivots(ctx, pivotQueue, journal, results)
+	}
+
+	close(results)
+	<-done
+
+	// Print summary
+	r.printSummary(allResults, resultFilter)
+
+	return nil
+}
+
+// scanCloudServices checks every {keyword} x AllCloudServices target for
+// an anonymous hit, reporting any that answer 200/403/401. Each live
+// target's TLS certificate is probed for SANs, which are sent to
+// pivotQueue for later expansion. discoveredVia tags emitted results
+// (see scanStorage).
+func (r *FullCloudRecon) scanCloudServices(ctx context.Context, keywords []string, discoveredVia string, results chan<- *FullCloudResult, journal *targetJournal, pivotQueue chan<- pivotHit) {
+	var wg sync.WaitGroup
 
 	// Create work channel for cloud services
 	cloudWork := make(chan providers.CloudTarget, 100000)
 
 	// Generate all targets
 	go func() {
-		for _, kw := range allKeywords[:min(len(allKeywords), 50)] { // Limit keywords
+		for _, kw := range keywords[:min(len(keywords), 50)] { // Limit keywords
 			r.cloudEnumerator.GenerateAllTargets(ctx, kw, cloudWork)
 		}
 		close(cloudWork)
@@ -192,20 +390,32 @@ func (r *FullCloudRecon) ScanAll(ctx context.Context, keyword string, outputPath
 				default:
 				}
 
+				if journal.shouldSkip(target) {
+					continue
+				}
+				recID := journal.recordPending(target)
+
+				r.limiter.Wait(target.Service.Provider)
 				result := r.cloudEnumerator.CheckTarget(ctx, target)
 				r.checked.Add(1)
+				if result != nil {
+					r.limiter.ReportResult(target.Service.Provider, net.IsThrottled(result.Status, nil))
+				}
+
+				journal.recordDone(recID, target)
 
 				if result != nil && (result.Status == 200 || result.Status == 403 || result.Status == 401) {
 					fullResult := &FullCloudResult{
-						URL:         result.URL,
-						Provider:    result.Provider,
-						ServiceType: result.ServiceType,
-						Region:      result.Region,
-						Status:      result.Status,
-						Severity:    result.Severity,
-						Accessible:  result.Accessible,
-						Timestamp:   time.Now().Format(time.RFC3339),
-						Category:    categorizeService(result.ServiceType),
+						URL:           result.URL,
+						Provider:      result.Provider,
+						ServiceType:   result.ServiceType,
+						Region:        result.Region,
+						Status:        result.Status,
+						Severity:      result.Severity,
+						Accessible:    result.Accessible,
+						Timestamp:     time.Now().Format(time.RFC3339),
+						Category:      categorizeService(result.ServiceType),
+						DiscoveredVia: discoveredVia,
 					}
 
 					if result.Status == 403 || result.Status == 401 {
@@ -215,23 +425,27 @@ func (r *FullCloudRecon) ScanAll(ctx context.Context, keyword string, outputPath
 					}
 
 					results <- fullResult
+					writeReproducer(r.config.ReproducerDir, recID, result.URL, result.Headers)
+
+					host := hostOf(result.URL)
+					for _, san := range captureSANs(host, r.config.Timeout) {
+						select {
+						case pivotQueue <- pivotHit{SAN: san, ParentHost: host}:
+						default:
+						}
+					}
 				}
 			}
 		}()
 	}
 
 	wg.Wait()
-	close(results)
-	<-done
-
-	// Print summary
-	r.printSummary(allResults)
-
-	return nil
 }
 
-// scanStorage handles S3, Azure Blob, and GCS bucket enumeration
-func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, results chan<- *FullCloudResult) {
+// scanStorage handles S3, Azure Blob, and GCS bucket enumeration.
+// discoveredVia tags every emitted result's DiscoveredVia field; pass ""
+// for a top-level scan and the parent host for a pivot round.
+func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, discoveredVia string, results chan<- *FullCloudResult) {
 	var wg sync.WaitGroup
 
 	// S3 workload
@@ -246,20 +460,25 @@ func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, res
 		go func() {
 			defer wg.Done()
 			for url := range s3Work {
+				r.limiter.Wait(r.s3Provider.Name())
 				result, err := r.s3Provider.Check(ctx, url)
 				r.checked.Add(1)
+				if result != nil {
+					r.limiter.ReportResult(r.s3Provider.Name(), net.IsThrottled(result.Status, nil))
+				}
 				if err == nil && result != nil {
 					results <- &FullCloudResult{
-						URL:         result.URL,
-						Provider:    result.Provider,
-						ServiceType: "S3 Bucket",
-						Status:      result.Status,
-						Permissions: result.Permissions,
-						Severity:    classifyStorageSeverity(result.Permissions),
-						Accessible:  result.Status == 200,
-						Files:       result.Files,
-						Timestamp:   time.Now().Format(time.RFC3339),
-						Category:    "storage",
+						URL:           result.URL,
+						Provider:      result.Provider,
+						ServiceType:   "S3 Bucket",
+						Status:        result.Status,
+						Permissions:   result.Permissions,
+						Severity:      classifyStorageSeverity(result.Permissions),
+						Accessible:    result.Status == 200,
+						Files:         result.Files,
+						Timestamp:     time.Now().Format(time.RFC3339),
+						Category:      "storage",
+						DiscoveredVia: discoveredVia,
 					}
 				}
 			}
@@ -278,20 +497,25 @@ func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, res
 		go func() {
 			defer wg.Done()
 			for url := range azureWork {
+				r.limiter.Wait(r.azureProvider.Name())
 				result, err := r.azureProvider.Check(ctx, url)
 				r.checked.Add(1)
+				if result != nil {
+					r.limiter.ReportResult(r.azureProvider.Name(), net.IsThrottled(result.Status, nil))
+				}
 				if err == nil && result != nil {
 					results <- &FullCloudResult{
-						URL:         result.URL,
-						Provider:    result.Provider,
-						ServiceType: "Blob Storage",
-						Status:      result.Status,
-						Permissions: result.Permissions,
-						Severity:    classifyStorageSeverity(result.Permissions),
-						Accessible:  result.Status == 200,
-						Files:       result.Files,
-						Timestamp:   time.Now().Format(time.RFC3339),
-						Category:    "storage",
+						URL:           result.URL,
+						Provider:      result.Provider,
+						ServiceType:   "Blob Storage",
+						Status:        result.Status,
+						Permissions:   result.Permissions,
+						Severity:      classifyStorageSeverity(result.Permissions),
+						Accessible:    result.Status == 200,
+						Files:         result.Files,
+						Timestamp:     time.Now().Format(time.RFC3339),
+						Category:      "storage",
+						DiscoveredVia: discoveredVia,
 					}
 				}
 			}
@@ -310,20 +534,25 @@ func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, res
 		go func() {
 			defer wg.Done()
 			for url := range gcsWork {
+				r.limiter.Wait(r.gcsProvider.Name())
 				result, err := r.gcsProvider.Check(ctx, url)
 				r.checked.Add(1)
+				if result != nil {
+					r.limiter.ReportResult(r.gcsProvider.Name(), net.IsThrottled(result.Status, nil))
+				}
 				if err == nil && result != nil {
 					results <- &FullCloudResult{
-						URL:         result.URL,
-						Provider:    result.Provider,
-						ServiceType: "GCS Bucket",
-						Status:      result.Status,
-						Permissions: result.Permissions,
-						Severity:    classifyStorageSeverity(result.Permissions),
-						Accessible:  result.Status == 200,
-						Files:       result.Files,
-						Timestamp:   time.Now().Format(time.RFC3339),
-						Category:    "storage",
+						URL:           result.URL,
+						Provider:      result.Provider,
+						ServiceType:   "GCS Bucket",
+						Status:        result.Status,
+						Permissions:   result.Permissions,
+						Severity:      classifyStorageSeverity(result.Permissions),
+						Accessible:    result.Status == 200,
+						Files:         result.Files,
+						Timestamp:     time.Now().Format(time.RFC3339),
+						Category:      "storage",
+						DiscoveredVia: discoveredVia,
 					}
 				}
 			}
@@ -333,7 +562,295 @@ func (r *FullCloudRecon) scanStorage(ctx context.Context, keywords []string, res
 	wg.Wait()
 }
 
-func (r *FullCloudRecon) printSummary(results []*FullCloudResult) {
+// scanOSINT runs osintAggregator against keyword and checks every
+// candidate URL it reports the same way a generated storage candidate
+// is checked, via a plain client.Check (the URLs cover S3/Azure
+// Blob/GCS hosts and arbitrary domains alike, so there's no single
+// provider to route through). discoveredVia tags emitted results (see
+// scanStorage).
+func (r *FullCloudRecon) scanOSINT(ctx context.Context, keyword string, discoveredVia string, results chan<- *FullCloudResult) {
+	candidates := make(chan string, 1000)
+	go func() {
+		defer close(candidates)
+		r.osintAggregator.Run(ctx, keyword, candidates)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.config.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range candidates {
+				status, _, err := r.httpClient.Check(target)
+				r.checked.Add(1)
+				if err != nil || status == 404 {
+					continue
+				}
+
+				results <- &FullCloudResult{
+					URL:           target,
+					Provider:      "OSINT",
+					ServiceType:   "Passive Discovery",
+					Status:        status,
+					Permissions:   "FOUND_VIA_OSINT",
+					Severity:      classifyStorageSeverity("FOUND_VIA_OSINT"),
+					Accessible:    status == 200,
+					Timestamp:     time.Now().Format(time.RFC3339),
+					Category:      "storage",
+					DiscoveredVia: discoveredVia,
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// scanMetadata runs metadataProvider against every keyword (treated as
+// a candidate SSRF-capable host, per its own Generate/Check contract -
+// see pkg/providers/metadata) and reports any exposed cloud instance
+// metadata as a credential-exposure finding.
+func (r *FullCloudRecon) scanMetadata(ctx context.Context, keywords []string, discoveredVia string, results chan<- *FullCloudResult) {
+	candidates := make(chan string, 1000)
+	go func() {
+		defer close(candidates)
+		for _, kw := range keywords {
+			r.metadataProvider.Generate(ctx, kw, candidates)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.config.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range candidates {
+				result, err := r.metadataProvider.Check(ctx, target)
+				r.checked.Add(1)
+				if err != nil || result == nil {
+					continue
+				}
+
+				results <- &FullCloudResult{
+					URL:           result.URL,
+					Provider:      result.Provider,
+					ServiceType:   "Cloud Metadata SSRF",
+					Status:        result.Status,
+					Permissions:   result.Permissions,
+					Severity:      classifyStorageSeverity(result.Permissions),
+					Accessible:    true,
+					Files:         result.Files,
+					Timestamp:     time.Now().Format(time.RFC3339),
+					Category:      "credential_exposure",
+					DiscoveredVia: discoveredVia,
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// scanCloudFront runs cloudfrontProvider against every keyword,
+// resolving each one's conventional subdomains (see pkg/dns) and
+// fingerprinting (see pkg/fingerprint) any that CNAME onto a known
+// CDN/storage edge for a dangling-backend takeover.
+func (r *FullCloudRecon) scanCloudFront(ctx context.Context, keywords []string, discoveredVia string, results chan<- *FullCloudResult) {
+	candidates := make(chan string, 1000)
+	go func() {
+		defer close(candidates)
+		for _, kw := range keywords {
+			r.cloudfrontProvider.Generate(ctx, kw, candidates)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.config.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range candidates {
+				result, err := r.cloudfrontProvider.Check(ctx, target)
+				r.checked.Add(1)
+				if err != nil || result == nil {
+					continue
+				}
+
+				results <- &FullCloudResult{
+					URL:           result.URL,
+					Provider:      result.Provider,
+					ServiceType:   "CDN Takeover",
+					Status:        result.Status,
+					Permissions:   result.Permissions,
+					Severity:      classifyStorageSeverity(result.Permissions),
+					Accessible:    true,
+					Timestamp:     time.Now().Format(time.RFC3339),
+					Category:      "takeover",
+					DiscoveredVia: discoveredVia,
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// pivotSeed is one SAN queued for expansion, along with the host whose
+// certificate it came from.
+type pivotSeed struct {
+	san        string
+	parentHost string
+}
+
+// expandPivots re-scans every TLS SAN observed during the initial scan
+// (and, recursively, during earlier pivot rounds) as a new seed keyword
+// across storage, cloud services, container registries, and CDN takeover
+// detection, up to Config.MaxPivotDepth rounds. A SAN is expanded at
+// most once across the whole run (see pivotSeen).
+func (r *FullCloudRecon) expandPivots(ctx context.Context, initial <-chan pivotHit, journal *targetJournal, results chan<- *FullCloudResult) {
+	frontier := r.dedupePivotHits(initial)
+
+	for depth := 1; depth <= r.config.MaxPivotDepth && len(frontier) > 0; depth++ {
+		fmt.Printf("[*] Phase 7: Pivot depth %d/%d - re-scanning %d discovered SAN(s)...\n", depth, r.config.MaxPivotDepth, len(frontier))
+
+		nextQueue := make(chan pivotHit, 10000)
+		var wg sync.WaitGroup
+
+		for _, seed := range frontier {
+			pivotKeywords := append([]string{seed.san}, r.permuter.GenerateAdvanced(seed.san)...)
+
+			wg.Add(1)
+			go func(keywords []string, parentHost string) {
+				defer wg.Done()
+				r.scanStorage(ctx, keywords, parentHost, results)
+			}(pivotKeywords, seed.parentHost)
+
+			wg.Add(1)
+			go func(keywords []string, parentHost string) {
+				defer wg.Done()
+				r.scanCloudServices(ctx, keywords, parentHost, results, journal, nextQueue)
+			}(pivotKeywords, seed.parentHost)
+
+			wg.Add(1)
+			go func(keywords []string, parentHost string) {
+				defer wg.Done()
+				r.scanContainerRegistries(ctx, keywords, parentHost, results)
+			}(pivotKeywords, seed.parentHost)
+
+			wg.Add(1)
+			go func(keywords []string, parentHost string) {
+				defer wg.Done()
+				r.scanCloudFront(ctx, keywords, parentHost, results)
+			}(pivotKeywords, seed.parentHost)
+		}
+
+		wg.Wait()
+		close(nextQueue)
+		frontier = r.dedupePivotHits(nextQueue)
+	}
+}
+
+// dedupePivotHits drains queue and returns the subset of hits whose SAN
+// hasn't already been expanded this run.
+func (r *FullCloudRecon) dedupePivotHits(queue <-chan pivotHit) []pivotSeed {
+	var frontier []pivotSeed
+	for hit := range queue {
+		if !r.pivotSeen.markSeen(hit.SAN) {
+			continue
+		}
+		frontier = append(frontier, pivotSeed{san: hit.SAN, parentHost: hit.ParentHost})
+	}
+	return frontier
+}
+
+// scanContainerRegistries probes the HTTP v2 registry API on AWS ECR,
+// Azure ACR, GCP GCR/Artifact Registry, GitHub GHCR, and Docker Hub for
+// every keyword, classifying each hit as a public catalog, a public tag
+// list, or auth-required. discoveredVia tags emitted results (see
+// scanStorage).
+func (r *FullCloudRecon) scanContainerRegistries(ctx context.Context, keywords []string, discoveredVia string, results chan<- *FullCloudResult) {
+	var wg sync.WaitGroup
+
+	work := make(chan registry.Target, len(keywords)*8)
+	go func() {
+		for _, kw := range keywords {
+			for _, target := range r.registryProvider.Targets(kw) {
+				select {
+				case <-ctx.Done():
+				case work <- target:
+				}
+			}
+		}
+		close(work)
+	}()
+
+	for i := 0; i < r.config.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range work {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				result, err := r.registryProvider.Check(ctx, target)
+				r.checked.Add(1)
+				if err != nil || result == nil {
+					continue
+				}
+
+				results <- &FullCloudResult{
+					URL:           result.URL,
+					Provider:      result.Provider,
+					ServiceType:   "Container Registry",
+					Region:        target.Region,
+					Status:        result.Status,
+					Permissions:   result.Permissions,
+					Severity:      registry.Severity(result.Provider, result.Permissions),
+					Accessible:    result.Permissions == registry.PublicCatalog || result.Permissions == registry.PublicTags,
+					Files:         result.Files,
+					Timestamp:     time.Now().Format(time.RFC3339),
+					Category:      "container",
+					DiscoveredVia: discoveredVia,
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// toScanResult adapts a FullCloudResult to the ScanResult shape
+// OutputSink expects (see pkg/engine/sink.go). CloudInfo
+// has no FullCloudResult equivalent and is left nil.
+func toScanResult(result *FullCloudResult) *ScanResult {
+	return &ScanResult{
+		Provider:      result.Provider,
+		URL:           result.URL,
+		Status:        result.Status,
+		Permissions:   result.Permissions,
+		Files:         result.Files,
+		Region:        result.Region,
+		Timestamp:     result.Timestamp,
+		ServiceType:   result.ServiceType,
+		Severity:      result.Severity,
+		Category:      result.Category,
+		Accessible:    result.Accessible,
+		DiscoveredVia: result.DiscoveredVia,
+	}
+}
+
+// matchesFilter reports whether result satisfies resultFilter. A
+// record that fails to marshal is treated as non-matching rather than
+// aborting the scan.
+func matchesFilter(resultFilter filter.Expr, result *FullCloudResult) bool {
+	record, err := filter.RecordFrom(result)
+	if err != nil {
+		return false
+	}
+	return resultFilter.Eval(record)
+}
+
+func (r *FullCloudRecon) printSummary(results []*FullCloudResult, resultFilter filter.Expr) {
 	elapsed := time.Since(r.startTime)
 
 	// Count by category
@@ -342,6 +859,9 @@ func (r *FullCloudRecon) printSummary(results []*FullCloudResult) {
 	severities := make(map[string]int)
 
 	for _, res := range results {
+		if !matchesFilter(resultFilter, res) {
+			continue
+		}
 		categories[res.Category]++
 		providers[res.Provider]++
 		severities[res.Severity]++
@@ -355,6 +875,13 @@ func (r *FullCloudRecon) printSummary(results []*FullCloudResult) {
 	fmt.Printf(" Found: %d assets\n", r.found.Load())
 	fmt.Printf(" Rate: %.2f checks/sec\n", float64(r.checked.Load())/elapsed.Seconds())
 
+	httpHits, httpMisses := r.httpClient.CacheStats()
+	dnsHits, dnsMisses := r.dnsResolver.CacheStats()
+	if httpHits+httpMisses+dnsHits+dnsMisses > 0 {
+		fmt.Printf(" Cache: %d/%d hits HTTP, %d/%d hits DNS\n",
+			httpHits, httpHits+httpMisses, dnsHits, dnsHits+dnsMisses)
+	}
+
 	fmt.Println("\n By Category:")
 	for cat, count := range categories {
 		fmt.Printf("   %-15s %d\n", cat, count)
@@ -405,7 +932,7 @@ func contains(s string, substrs ...string) bool {
 
 func classifyStorageSeverity(permissions string) string {
 	switch permissions {
-	case "PUBLIC_READ", "PUBLIC_LIST", "PUBLIC_WRITE":
+	case "PUBLIC_READ", "PUBLIC_LIST", "PUBLIC_WRITE", "IMDS_EXPOSED", "TAKEOVER_CANDIDATE":
 		return "CRITICAL"
 	case "AUTHENTICATED":
 		return "HIGH"
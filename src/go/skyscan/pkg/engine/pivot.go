@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// pivotHit is one TLS SAN observed on a scanned target's certificate,
+// paired with the host whose certificate produced it.
+type pivotHit struct {
+	SAN        string
+	ParentHost string
+}
+
+// pivotSeen is a concurrent-safe set of SANs already expanded into a
+// pivot scan, mirroring targetJournal's mutex-protected visited map so
+// the same SAN is never re-scanned twice across the whole run.
+type pivotSeen struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newPivotSeen() *pivotSeen {
+	return &pivotSeen{seen: make(map[string]bool)}
+}
+
+// markSeen reports whether san is newly added to the set.
+func (p *pivotSeen) markSeen(san string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen[san] {
+		return false
+	}
+	p.seen[san] = true
+	return true
+}
+
+// captureSANs dials host:443 and returns the DNSNames on its leaf TLS
+// certificate, or nil if the dial/handshake fails. Verification is
+// skipped (InsecureSkipVerify) since only the certificate's advertised
+// names are needed, not a trust decision.
+func captureSANs(host string, timeoutSeconds int) []string {
+	if host == "" {
+		return nil
+	}
+
+	dialer := &net.Dialer{Timeout: time.Duration(timeoutSeconds) * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", fmt.Sprintf("%s:443", host), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil
+	}
+	return certs[0].DNSNames
+}
+
+// hostOf extracts the bare hostname from a target URL (scheme, port,
+// and path stripped), for feeding into captureSANs.
+func hostOf(target string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.Hostname() == "" {
+		return target
+	}
+	return u.Hostname()
+}
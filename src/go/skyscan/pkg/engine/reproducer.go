@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ReproducerArtifact is everything needed to replay a single finding
+// outside the engine: the exact request that produced it, plus the
+// connection details observed at the time.
+type ReproducerArtifact struct {
+	ID          int64             `json:"id"`
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	ResolvedIP  string            `json:"resolved_ip,omitempty"`
+	TLSVersion  string            `json:"tls_version,omitempty"`
+	TLSCipher   string            `json:"tls_cipher,omitempty"`
+	Timestamp   string            `json:"timestamp"`
+}
+
+// writeReproducer probes target directly (outside the fast enumeration
+// path) to capture the resolved IP and TLS details, then writes the
+// result as "<dir>/<id>.json" so `skyscan reproduce <id>` can replay it
+// later.
+func writeReproducer(dir string, id int64, target string, headers map[string]string) {
+	if dir == "" {
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Printf("[!] Failed to create reproducer dir %s: %v\n", dir, err)
+		return
+	}
+
+	artifact := ReproducerArtifact{
+		ID:        id,
+		Method:    "GET",
+		URL:       target,
+		Headers:   headers,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if host := hostnameOf(target); host != "" {
+		if ips, err := net.LookupIP(host); err == nil && len(ips) > 0 {
+			artifact.ResolvedIP = ips[0].String()
+		}
+	}
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	if resp, err := client.Get(target); err == nil {
+		resp.Body.Close()
+		if resp.TLS != nil {
+			artifact.TLSVersion = tlsVersionName(resp.TLS.Version)
+			artifact.TLSCipher = tls.CipherSuiteName(resp.TLS.CipherSuite)
+		}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", id))
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("[!] Failed to write reproducer artifact %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	json.NewEncoder(f).Encode(artifact)
+}
+
+func hostnameOf(target string) string {
+	host := strings.TrimPrefix(target, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return strings.Split(host, "/")[0]
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("Unknown (%x)", version)
+	}
+}
+
+// Reproduce replays the request recorded in "<dir>/<id>.json" and
+// prints the response status, so a user can re-check a single finding
+// without re-running the whole scan.
+func Reproduce(dir string, id int64) error {
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", id))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read reproducer artifact %s: %w", path, err)
+	}
+
+	var artifact ReproducerArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return fmt.Errorf("failed to parse reproducer artifact %s: %w", path, err)
+	}
+
+	req, err := http.NewRequest(artifact.Method, artifact.URL, strings.NewReader(artifact.Body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	for k, v := range artifact.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	fmt.Printf("[*] Replaying %s %s (originally resolved %s, %s)\n",
+		artifact.Method, artifact.URL, artifact.ResolvedIP, artifact.Timestamp)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Printf("[*] Status: %d\n", resp.StatusCode)
+	return nil
+}
@@ -2,7 +2,10 @@ package engine
 
 import (
 	"context"
+	"fmt"
+	"skyscan/pkg/checkpoint"
 	"skyscan/pkg/core"
+	"skyscan/pkg/net"
 	"sync"
 )
 
@@ -10,14 +13,28 @@ type Runner struct {
 	providers []core.Provider
 	config    *core.Config
 	results   chan *core.Result
+	resume    *checkpoint.Store
+	limiter   *net.ProviderLimiter
 }
 
 func NewRunner(config *core.Config, providers []core.Provider) *Runner {
-	return &Runner{
+	r := &Runner{
 		providers: providers,
 		config:    config,
 		results:   make(chan *core.Result),
+		limiter:   net.NewProviderLimiter(float64(config.RateLimit), config.Burst),
 	}
+
+	if config.Resume != "" {
+		store, err := checkpoint.Open(config.Resume)
+		if err != nil {
+			fmt.Printf("[!] Failed to open checkpoint file %s: %v\n", config.Resume, err)
+		} else {
+			r.resume = store
+		}
+	}
+
+	return r
 }
 
 func (r *Runner) Start(ctx context.Context, keyword string) <-chan *core.Result {
@@ -37,7 +54,7 @@ func (r *Runner) Start(ctx context.Context, keyword string) <-chan *core.Result
 			pChan := make(chan string)
 			go func() {
 				defer close(pChan)
-				prov.Generate(ctx, keyword, pChan)
+				checkpoint.Generate(ctx, r.resume, keyword, prov, pChan)
 			}()
 
 			for target := range pChan {
@@ -70,7 +87,11 @@ func (r *Runner) Start(ctx context.Context, keyword string) <-chan *core.Result
 				case <-ctx.Done():
 					return
 				default:
+					r.limiter.Wait(job.Provider.Name())
 					res, err := job.Provider.Check(ctx, job.Target)
+					if res != nil {
+						r.limiter.ReportResult(job.Provider.Name(), net.IsThrottled(res.Status, nil))
+					}
 					if err == nil && res != nil {
 						r.results <- res
 					}
@@ -83,7 +104,20 @@ func (r *Runner) Start(ctx context.Context, keyword string) <-chan *core.Result
 	go func() {
 		wgWorkers.Wait()
 		close(r.results)
+		if r.resume != nil {
+			r.resume.Close()
+		}
 	}()
 
 	return r.results
 }
+
+// GetStats returns each provider's current effective requests/sec,
+// reflecting any ProviderLimiter backoff/restore since the scan began.
+func (r *Runner) GetStats() map[string]float64 {
+	rates := make(map[string]float64, len(r.providers))
+	for _, p := range r.providers {
+		rates[p.Name()] = r.limiter.EffectiveRate(p.Name())
+	}
+	return rates
+}
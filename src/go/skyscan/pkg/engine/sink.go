@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"skyscan/pkg/netmapper"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ScanResult is the shape OutputSink writes, built from a
+// FullCloudResult by toScanResult (see full_recon.go).
+type ScanResult struct {
+	Provider    string               `json:"provider"`
+	URL         string               `json:"url"`
+	Status      int                  `json:"status"`
+	Permissions string               `json:"permissions"`
+	Files       []string             `json:"files,omitempty"`
+	Region      string               `json:"region,omitempty"`
+	CloudInfo   *netmapper.CloudInfo `json:"cloud_info,omitempty"`
+	Timestamp   string               `json:"timestamp"`
+	ServiceType string               `json:"service_type,omitempty"`
+	Severity    string               `json:"severity,omitempty"`
+	Category    string               `json:"category,omitempty"`
+	Accessible  bool                 `json:"accessible,omitempty"`
+
+	// DiscoveredVia names the parent host whose TLS certificate SAN led
+	// to this result being scanned as a pivot seed. Empty for results
+	// found directly from the original keyword/mutations.
+	DiscoveredVia string `json:"discovered_via,omitempty"`
+}
+
+// OutputSink is where ScanAll sends each ScanResult as it's found,
+// independent of the console summary FullCloudRecon always prints.
+// Formats differ in whether they can stream a result as it arrives
+// (NDJSON, CSV, the Prometheus metrics sink) or must buffer the whole
+// run and emit one document on Close (SARIF has no append-a-result
+// form).
+type OutputSink interface {
+	Write(result *ScanResult) error
+	Close() error
+}
+
+// newOutputSink builds the OutputSink for format ("", "ndjson", "csv",
+// "sarif", or "metrics"; "" defaults to "ndjson"). path is a file path
+// for every format except "metrics", where it's the listen address
+// (e.g. ":9090") a Prometheus server scrapes /metrics from. An empty
+// path disables output entirely (Scan still collects results in
+// memory and prints its console summary).
+func newOutputSink(path, format string) (OutputSink, error) {
+	if format == "" {
+		format = "ndjson"
+	}
+
+	if path == "" {
+		return noopSink{}, nil
+	}
+
+	switch format {
+	case "ndjson":
+		return newNDJSONSink(path)
+	case "csv":
+		return newCSVSink(path)
+	case "sarif":
+		return newSARIFSink(path)
+	case "metrics":
+		return newMetricsSink(path)
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want ndjson, csv, sarif, or metrics)", format)
+	}
+}
+
+// noopSink discards every result, used when no output path is given.
+type noopSink struct{}
+
+func (noopSink) Write(*ScanResult) error { return nil }
+func (noopSink) Close() error            { return nil }
+
+// ndjsonSink writes one flushed JSON object per line, the existing
+// behavior Scan had before OutputSink existed.
+type ndjsonSink struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newNDJSONSink(path string) (*ndjsonSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return &ndjsonSink{file: f, encoder: json.NewEncoder(f)}, nil
+}
+
+func (s *ndjsonSink) Write(result *ScanResult) error {
+	return s.encoder.Encode(result)
+}
+
+func (s *ndjsonSink) Close() error {
+	return s.file.Close()
+}
+
+// csvSink writes a header row on the first result, then one row per
+// result after. Files/CloudInfo aren't representable as a column, so
+// Files is joined with ";" and CloudInfo is dropped - both are still
+// available in full via the ndjson/sarif formats.
+type csvSink struct {
+	file      *os.File
+	writer    *csv.Writer
+	wroteHead bool
+}
+
+var csvHeader = []string{"provider", "url", "status", "permissions", "region", "files", "timestamp", "service_type", "severity", "category", "accessible", "discovered_via"}
+
+func newCSVSink(path string) (*csvSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return &csvSink{file: f, writer: csv.NewWriter(f)}, nil
+}
+
+func (s *csvSink) Write(result *ScanResult) error {
+	if !s.wroteHead {
+		if err := s.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		s.wroteHead = true
+	}
+
+	row := []string{
+		result.Provider,
+		result.URL,
+		strconv.Itoa(result.Status),
+		result.Permissions,
+		result.Region,
+		joinFiles(result.Files),
+		result.Timestamp,
+		result.ServiceType,
+		result.Severity,
+		result.Category,
+		strconv.FormatBool(result.Accessible),
+		result.DiscoveredVia,
+	}
+	if err := s.writer.Write(row); err != nil {
+		return err
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *csvSink) Close() error {
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+func joinFiles(files []string) string {
+	joined := ""
+	for i, f := range files {
+		if i > 0 {
+			joined += ";"
+		}
+		joined += f
+	}
+	return joined
+}
+
+// sarifSink buffers every result and emits one SARIF 2.1.0 log on
+// Close - unlike NDJSON/CSV, SARIF's "runs[].results" array isn't
+// meant to be appended to a line at a time, so there's nothing
+// meaningful to flush per-result.
+type sarifSink struct {
+	path    string
+	results []*ScanResult
+}
+
+func newSARIFSink(path string) (*sarifSink, error) {
+	return &sarifSink{path: path}, nil
+}
+
+func (s *sarifSink) Write(result *ScanResult) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+func (s *sarifSink) Close() error {
+	f, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	log := sarifLog{Schema: sarifSchemaURI, Version: sarifVersion}
+	run := sarifRun{}
+	run.Tool.Driver.Name = "skyscan"
+	for _, result := range s.results {
+		sarifResult := sarifResult{
+			RuleID:  result.Provider,
+			Message: sarifMessage{Text: fmt.Sprintf("%s - %s (%d)", result.Provider, result.Permissions, result.Status)},
+		}
+		sarifResult.Locations = []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: result.URL},
+			},
+		}}
+		run.Results = append(run.Results, sarifResult)
+	}
+	log.Runs = []sarifRun{run}
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+const (
+	sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion   = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name string `json:"name"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// metricsSink serves a Prometheus-style /metrics endpoint at addr for
+// the life of the scan, so a scrape mid-run sees up-to-date per-provider
+// finding counts instead of waiting for the scan to finish.
+type metricsSink struct {
+	server *http.Server
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newMetricsSink(addr string) (*metricsSink, error) {
+	s := &metricsSink{counts: make(map[string]int64)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	s.server = &http.Server{Addr: addr, Handler: mux}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start metrics listener: %w", err)
+	}
+	go s.server.Serve(ln)
+
+	return s, nil
+}
+
+func (s *metricsSink) Write(result *ScanResult) error {
+	s.mu.Lock()
+	s.counts[result.Provider]++
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *metricsSink) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	providers := make([]string, 0, len(s.counts))
+	for provider := range s.counts {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	fmt.Fprintln(w, "# HELP skyscan_findings_total Cloud assets found, by provider.")
+	fmt.Fprintln(w, "# TYPE skyscan_findings_total counter")
+	for _, provider := range providers {
+		fmt.Fprintf(w, "skyscan_findings_total{provider=%q} %d\n", provider, s.counts[provider])
+	}
+	s.mu.Unlock()
+}
+
+func (s *metricsSink) Close() error {
+	return s.server.Shutdown(context.Background())
+}
@@ -0,0 +1,284 @@
+// Package filter implements the small boolean expression language used
+// to select which FullCloudRecon results are reported, e.g.:
+//
+//	Category == "storage" and Severity in ["CRITICAL","HIGH"] and Provider matches "aws.*"
+//
+// Field names are matched case-insensitively against the result's JSON
+// tags, so "Category" and "category" are equivalent.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Expr is a parsed filter expression that can be evaluated against a
+// result record.
+type Expr interface {
+	Eval(record map[string]string) bool
+}
+
+// Parse compiles expr into an Expr ready for repeated evaluation. An
+// empty expr matches everything.
+func Parse(expr string) (Expr, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return matchAll{}, nil
+	}
+
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	ast, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+	return ast, nil
+}
+
+// RecordFrom converts any JSON-taggable value (e.g. *FullCloudResult)
+// into the string-keyed record Expr.Eval expects, so the filter
+// language works against every JSON field without the filter package
+// needing to know the engine's types. Keys are lowercased so a filter
+// expression's field names can be written in whatever case reads best
+// (e.g. "Category" or "category") regardless of the struct's own
+// (typically lowercase) json tags - see compareExpr/inExpr.Eval.
+func RecordFrom(v interface{}) (map[string]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	record := make(map[string]string, len(raw))
+	for k, val := range raw {
+		record[strings.ToLower(k)] = fmt.Sprintf("%v", val)
+	}
+	return record, nil
+}
+
+// --- AST ---
+
+type matchAll struct{}
+
+func (matchAll) Eval(map[string]string) bool { return true }
+
+type andExpr struct{ left, right Expr }
+
+func (e andExpr) Eval(record map[string]string) bool {
+	return e.left.Eval(record) && e.right.Eval(record)
+}
+
+type orExpr struct{ left, right Expr }
+
+func (e orExpr) Eval(record map[string]string) bool {
+	return e.left.Eval(record) || e.right.Eval(record)
+}
+
+type notExpr struct{ inner Expr }
+
+func (e notExpr) Eval(record map[string]string) bool {
+	return !e.inner.Eval(record)
+}
+
+type compareExpr struct {
+	field string
+	op    tokenKind // tokenEq, tokenNeq, or tokenMatches
+	value string
+	re    *regexp.Regexp // compiled once at parse time, only set for tokenMatches
+}
+
+func (e compareExpr) Eval(record map[string]string) bool {
+	actual := record[strings.ToLower(e.field)]
+	switch e.op {
+	case tokenEq:
+		return actual == e.value
+	case tokenNeq:
+		return actual != e.value
+	case tokenMatches:
+		if e.re == nil {
+			return false
+		}
+		return e.re.MatchString(actual)
+	default:
+		return false
+	}
+}
+
+type inExpr struct {
+	field  string
+	values []string
+}
+
+func (e inExpr) Eval(record map[string]string) bool {
+	actual := record[strings.ToLower(e.field)]
+	for _, v := range e.values {
+		if actual == v {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Recursive-descent parser ---
+//
+// Grammar:
+//
+//	orExpr   := andExpr ("or" andExpr)*
+//	andExpr  := unary ("and" unary)*
+//	unary    := "not" unary | primary
+//	primary  := "(" orExpr ")" | comparison
+//	comparison := IDENT ("==" | "!=" | "matches") STRING
+//	            | IDENT "in" "[" STRING ("," STRING)* "]"
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("unexpected token %q", t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	field, err := p.expect(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.next()
+	switch op.kind {
+	case tokenEq, tokenNeq:
+		val, err := p.expect(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{field: field.text, op: op.kind, value: val.text}, nil
+
+	case tokenMatches:
+		val, err := p.expect(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(val.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", val.text, err)
+		}
+		return compareExpr{field: field.text, op: op.kind, value: val.text, re: re}, nil
+
+	case tokenIn:
+		if _, err := p.expect(tokenLBracket); err != nil {
+			return nil, err
+		}
+		var values []string
+		for {
+			if p.peek().kind == tokenRBracket {
+				break
+			}
+			val, err := p.expect(tokenString)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, val.text)
+			if p.peek().kind == tokenComma {
+				p.next()
+				continue
+			}
+			break
+		}
+		if _, err := p.expect(tokenRBracket); err != nil {
+			return nil, err
+		}
+		return inExpr{field: field.text, values: values}, nil
+
+	default:
+		return nil, fmt.Errorf("expected an operator (==, !=, in, matches) after %q, got %q", field.text, op.text)
+	}
+}
@@ -0,0 +1,104 @@
+package filter
+
+import "testing"
+
+type testResult struct {
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Provider string `json:"provider"`
+}
+
+func TestParseAndEval(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		record testResult
+		want   bool
+	}{
+		{
+			name:   "empty expression matches everything",
+			expr:   "",
+			record: testResult{Category: "compute"},
+			want:   true,
+		},
+		{
+			name:   "equality",
+			expr:   `Category == "storage"`,
+			record: testResult{Category: "storage"},
+			want:   true,
+		},
+		{
+			name:   "inequality",
+			expr:   `Category != "storage"`,
+			record: testResult{Category: "compute"},
+			want:   true,
+		},
+		{
+			name:   "in list",
+			expr:   `Severity in ["CRITICAL","HIGH"]`,
+			record: testResult{Severity: "HIGH"},
+			want:   true,
+		},
+		{
+			name:   "matches regex",
+			expr:   `Provider matches "aws.*"`,
+			record: testResult{Provider: "aws-s3"},
+			want:   true,
+		},
+		{
+			name:   "and/or/not/parens",
+			expr:   `Category == "storage" and (Severity in ["CRITICAL","HIGH"] or not Provider matches "azure.*")`,
+			record: testResult{Category: "storage", Severity: "LOW", Provider: "aws-s3"},
+			want:   true,
+		},
+		{
+			// The exact example shipped in -h's usage text and -filter's
+			// own help string: field names are capitalized, but the
+			// record's json tags (and hence RecordFrom's map keys) are
+			// lowercase. This must still match.
+			name:   "documented example with capitalized field names",
+			expr:   `Category == "storage" and Severity in ["CRITICAL","HIGH"]`,
+			record: testResult{Category: "storage", Severity: "CRITICAL"},
+			want:   true,
+		},
+		{
+			name:   "field names are case-insensitive both ways",
+			expr:   `category == "storage"`,
+			record: testResult{Category: "storage"},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+			}
+
+			record, err := RecordFrom(tt.record)
+			if err != nil {
+				t.Fatalf("RecordFrom returned error: %v", err)
+			}
+
+			if got := expr.Eval(record); got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidExpressions(t *testing.T) {
+	tests := []string{
+		`Category ==`,
+		`Category == "storage" and`,
+		`Category "storage"`,
+		`Category == "unterminated`,
+	}
+
+	for _, expr := range tests {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) = nil error, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenIn
+	tokenMatches
+	tokenEq
+	tokenNeq
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var keywordTokens = map[string]tokenKind{
+	"and":     tokenAnd,
+	"or":      tokenOr,
+	"not":     tokenNot,
+	"in":      tokenIn,
+	"matches": tokenMatches,
+}
+
+// lex tokenizes a filter expression such as:
+//
+//	Category == "storage" and Severity in ["CRITICAL","HIGH"] and Provider matches "aws.*"
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	r := []rune(expr)
+	i := 0
+
+	for i < len(r) {
+		c := r[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{tokenLBracket, "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{tokenRBracket, "]"})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+
+		case c == '=' && i+1 < len(r) && r[i+1] == '=':
+			tokens = append(tokens, token{tokenEq, "=="})
+			i += 2
+		case c == '!' && i+1 < len(r) && r[i+1] == '=':
+			tokens = append(tokens, token{tokenNeq, "!="})
+			i += 2
+
+		case c == '"' || c == '\'':
+			quote := c
+			j := i + 1
+			var sb strings.Builder
+			for j < len(r) && r[j] != quote {
+				sb.WriteRune(r[j])
+				j++
+			}
+			if j >= len(r) {
+				return nil, fmt.Errorf("unterminated string literal starting at position %d", i)
+			}
+			tokens = append(tokens, token{tokenString, sb.String()})
+			i = j + 1
+
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(r) && (unicode.IsLetter(r[j]) || unicode.IsDigit(r[j]) || r[j] == '_' || r[j] == '.') {
+				j++
+			}
+			word := string(r[i:j])
+			if kind, ok := keywordTokens[strings.ToLower(word)]; ok {
+				tokens = append(tokens, token{kind, word})
+			} else {
+				tokens = append(tokens, token{tokenIdent, word})
+			}
+			i = j
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+
+	tokens = append(tokens, token{tokenEOF, ""})
+	return tokens, nil
+}
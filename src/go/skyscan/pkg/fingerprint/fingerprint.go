@@ -0,0 +1,127 @@
+// Package fingerprint classifies an HTTP status/body pair from a
+// storage-bucket or CDN-edge Check into one of a small taxonomy, so a
+// provider's Check doesn't have to hand-roll its own "is this body
+// interesting" substring matching - and so that matching is shared
+// across S3, Azure Blob, GCS, and CDN-edge providers instead of each
+// one growing its own slightly-different fingerprint list.
+package fingerprint
+
+import "strings"
+
+// Classification is the verdict Classify returns for one Check result.
+type Classification string
+
+const (
+	// ExistsPublic means the resource exists and is readable without
+	// credentials (e.g. an S3 bucket listing, a readable blob).
+	ExistsPublic Classification = "EXISTS_PUBLIC"
+
+	// ExistsPrivate means the resource exists but anonymous access was
+	// denied (e.g. an AccessDenied body on a 403).
+	ExistsPrivate Classification = "EXISTS_PRIVATE"
+
+	// NotFound means the resource doesn't exist - a key-not-found body
+	// or a bare 404. A bucket-not-found body matches danglingSignatures
+	// first and is classified TakeoverCandidate instead.
+	NotFound Classification = "NOT_FOUND"
+
+	// TakeoverCandidate means the body carries a dangling-backend
+	// fingerprint: whatever used to own this bucket/CNAME target is
+	// gone, and claiming it would let an attacker serve content under
+	// the victim's hostname.
+	TakeoverCandidate Classification = "TAKEOVER_CANDIDATE"
+
+	// WAFBlocked means the body is a WAF/bot-challenge or parked-domain
+	// lander, not a real answer from the storage/CDN backend at all -
+	// the result is inconclusive and should not be classified as any of
+	// the above.
+	WAFBlocked Classification = "WAF_BLOCKED"
+)
+
+// notFoundSignatures are response-body substrings confirming the
+// target object/bucket genuinely doesn't exist, across providers.
+// Classify checks danglingSignatures first, so of these only NoSuchKey
+// ever decides the result.
+var notFoundSignatures = []string{
+	"<Code>NoSuchBucket</Code>",
+	"<Code>NoSuchKey</Code>",
+	"BlobNotFound",
+	"The specified bucket does not exist",
+}
+
+// accessDeniedSignatures are response-body substrings confirming the
+// target exists but anonymous access was denied.
+var accessDeniedSignatures = []string{
+	"<Code>AccessDenied</Code>",
+	"AuthenticationRequired",
+}
+
+// danglingSignatures are response-body substrings that indicate a
+// CNAMEd/aliased resource's backend no longer exists - the hallmark of
+// a takeover-vulnerable dangling record, independent of which provider
+// the alias points at.
+var danglingSignatures = []string{
+	"NoSuchBucket",
+	"The specified bucket does not exist",
+	"Bad Request: The request could not be satisfied",
+	"ERROR: The request could not be satisfied",
+	"The Resource You Are Looking For Has Been Removed", // Azure CDN
+	"BlobNotFound",
+}
+
+// wafSignatures are response-body substrings from a WAF/bot-challenge
+// page or a parked-domain lander - not an answer from the real backend,
+// so none of the other signatures should be trusted if one of these
+// matches first.
+var wafSignatures = []string{
+	"Attention Required! | Cloudflare",
+	"cf-error-details",
+	"Access Denied - Akamai",
+	"<title>Request Rejected</title>",
+	"captcha-delivery.com",
+	"Incapsula incident ID",
+	"This domain is parked",
+	"Buy this domain",
+}
+
+// Classify inspects status and body (the first N KB is enough - see
+// net.Client.CheckWithBody) and returns the most specific taxonomy
+// entry that applies. WAF/parked-domain signatures are checked first
+// since they can otherwise coincidentally satisfy a NotFound/Dangling
+// substring match (many challenge pages echo back the requested path).
+func Classify(status int, body []byte) Classification {
+	text := string(body)
+
+	if containsAny(text, wafSignatures) {
+		return WAFBlocked
+	}
+	if containsAny(text, danglingSignatures) {
+		return TakeoverCandidate
+	}
+	if containsAny(text, notFoundSignatures) {
+		return NotFound
+	}
+	if containsAny(text, accessDeniedSignatures) {
+		return ExistsPrivate
+	}
+
+	switch {
+	case status == 200 || status == 204:
+		return ExistsPublic
+	case status == 403:
+		return ExistsPrivate
+	case status == 404:
+		return NotFound
+	default:
+		return ExistsPrivate
+	}
+}
+
+func containsAny(text string, signatures []string) bool {
+	for _, sig := range signatures {
+		if strings.Contains(text, sig) {
+			return true
+		}
+	}
+	return false
+}
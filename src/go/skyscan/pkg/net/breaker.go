@@ -0,0 +1,109 @@
+package net
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Check/GetBody instead of issuing a
+// request when that host's breaker has tripped and is still cooling
+// down.
+var ErrCircuitOpen = errors.New("net: circuit breaker open for host")
+
+// hostBreakerState tracks one host's consecutive-failure count and,
+// once tripped, how long it stays open.
+type hostBreakerState struct {
+	consecutiveFails int
+	openUntil        time.Time
+	cooldown         time.Duration
+}
+
+// hostBreaker opens per-eTLD+1 after threshold consecutive 5xx or
+// connection-reset responses, refusing further calls until an
+// exponentially increasing cooldown elapses.
+type hostBreaker struct {
+	threshold    int
+	baseCooldown time.Duration
+	mu           sync.Mutex
+	states       map[string]*hostBreakerState
+}
+
+func newHostBreaker(threshold int, baseCooldown time.Duration) *hostBreaker {
+	return &hostBreaker{
+		threshold:    threshold,
+		baseCooldown: baseCooldown,
+		states:       make(map[string]*hostBreakerState),
+	}
+}
+
+// allow reports whether a request to host may proceed. A nil receiver
+// or non-positive threshold disables the breaker entirely.
+func (b *hostBreaker) allow(host string) bool {
+	if b == nil || b.threshold <= 0 {
+		return true
+	}
+
+	key := etldPlusOne(host)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.states[key]
+	if !ok {
+		return true
+	}
+
+	return time.Now().After(st.openUntil)
+}
+
+// recordSuccess clears the failure streak for host, closing its
+// breaker if it had tripped.
+func (b *hostBreaker) recordSuccess(host string) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	key := etldPlusOne(host)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if st, ok := b.states[key]; ok {
+		st.consecutiveFails = 0
+		st.cooldown = 0
+	}
+}
+
+// recordFailure counts a 5xx/connection-reset response against host,
+// tripping (or re-tripping with a doubled cooldown) the breaker once
+// the threshold is reached.
+func (b *hostBreaker) recordFailure(host string) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	key := etldPlusOne(host)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.states[key]
+	if !ok {
+		st = &hostBreakerState{}
+		b.states[key] = st
+	}
+
+	st.consecutiveFails++
+	if st.consecutiveFails < b.threshold {
+		return
+	}
+
+	if st.cooldown == 0 {
+		st.cooldown = b.baseCooldown
+	} else {
+		st.cooldown *= 2
+	}
+	st.openUntil = time.Now().Add(st.cooldown)
+	st.consecutiveFails = 0
+}
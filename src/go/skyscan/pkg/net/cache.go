@@ -0,0 +1,143 @@
+package net
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCacheMaxSize bounds a responseCache when Config.CacheMaxSize
+// is left at its zero value.
+const defaultCacheMaxSize = 10000
+
+// positiveCacheTTL and negativeCacheTTL bound how long a responseCache
+// entry is trusted: a target that was found a moment ago is far less
+// likely to disappear mid-scan than a missing one is to appear, so
+// positive entries live ten times longer than negative ones.
+const (
+	positiveCacheTTL = 5 * time.Minute
+	negativeCacheTTL = 30 * time.Second
+)
+
+// cacheEntry is one LRU-tracked cache slot.
+type cacheEntry struct {
+	key      string
+	value    interface{}
+	expireAt time.Time
+}
+
+// responseCache is a bounded, TTL'd, LRU-evicted cache shared by
+// Client and DNSResolver so repeated probes against the same target
+// (which colliding keyword mutations produce constantly) don't
+// re-issue identical DNS lookups or HTTP requests.
+type responseCache struct {
+	maxEntries  int
+	positiveTTL time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// newResponseCache returns nil when maxEntries is non-positive, so a
+// disabled cache is just a nil *responseCache: every method below is
+// nil-receiver safe and behaves as an always-miss, do-nothing cache.
+func newResponseCache(maxEntries int, positiveTTL, negativeTTL time.Duration) *responseCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &responseCache{
+		maxEntries:  maxEntries,
+		positiveTTL: positiveTTL,
+		negativeTTL: negativeTTL,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+	}
+}
+
+// get returns the cached value for key, if present and unexpired.
+func (c *responseCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expireAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	c.hits.Add(1)
+	return entry.value, true
+}
+
+// set stores value under key, evicting the least-recently-used entry
+// once the cache is at capacity. negative selects the shorter TTL.
+func (c *responseCache) set(key string, value interface{}, negative bool) {
+	if c == nil {
+		return
+	}
+
+	ttl := c.positiveTTL
+	if negative {
+		ttl = c.negativeTTL
+	}
+	expireAt := time.Now().Add(ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.value = value
+		entry.expireAt = expireAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&cacheEntry{key: key, value: value, expireAt: expireAt})
+	c.entries[key] = el
+
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// clear empties the cache, letting a caller force full revalidation
+// without restarting the process.
+func (c *responseCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order = list.New()
+}
+
+// stats returns the cache's cumulative hit/miss counts.
+func (c *responseCache) stats() (hits, misses int64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
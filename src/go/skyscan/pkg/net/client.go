@@ -1,65 +1,392 @@
 package net
 
 import (
+	"fmt"
+	"net/url"
+	"os"
+	"skyscan/pkg/cache"
+	"skyscan/pkg/core"
 	"time"
 
 	"github.com/valyala/fasthttp"
 )
 
-// Client is a wrapper around fasthttp.Client
+// Client is a wrapper around fasthttp.Client with a per-host token
+// bucket and circuit breaker layered on top, so providers calling
+// Check/GetBody never need to coordinate rate limits themselves.
 type Client struct {
-	client *fasthttp.Client
+	client  *fasthttp.Client
+	limiter *hostLimiter
+	breaker *hostBreaker
+	cache   *responseCache
+	pacer   *Pacer
+
+	// diskCache is the persistent counterpart to cache above (see
+	// pkg/cache) - nil when Config.CachePath is empty or Config.NoCache
+	// is set. positiveTTL is the TTL a fresh positive Check result is
+	// stored with; negatives (404) always use
+	// cache.DefaultHTTPNegativeTTL.
+	diskCache   *cache.Store
+	positiveTTL time.Duration
+
+	// retry, set by NewClient unless Config.NoRetry, makes Check/GetBody
+	// retry a connection-level failure (reset, TLS handshake timeout,
+	// dial timeout) or a 429/503 under DefaultRetryStrategy instead of
+	// surfacing the first failure.
+	retry *RetryStrategy
+}
+
+// checkResult is the cached value for a Check call, keyed by target
+// URL.
+type checkResult struct {
+	status int
+	size   int64
 }
 
-// NewClient creates a new high-performance HTTP client
-func NewClient(timeout int) *Client {
-	return &Client{
+// NewClient creates a new high-performance HTTP client. PerHostQPS,
+// BreakerThreshold and BreakerCooldown on config are optional; zero
+// values disable the corresponding guard. The response cache is on by
+// default; set config.NoCache to force every call to revalidate.
+// DefaultRetryStrategy is also on by default; set config.NoRetry to
+// surface the first transient failure instead of retrying it.
+func NewClient(config *core.Config) *Client {
+	c := &Client{
 		client: &fasthttp.Client{
 			MaxConnsPerHost:               2000,
-			ReadTimeout:                   time.Duration(timeout) * time.Second,
-			WriteTimeout:                  time.Duration(timeout) * time.Second,
+			ReadTimeout:                   time.Duration(config.Timeout) * time.Second,
+			WriteTimeout:                  time.Duration(config.Timeout) * time.Second,
 			NoDefaultUserAgentHeader:      true, // We will rotate UAs manually if needed
 			DisableHeaderNamesNormalizing: true,
 		},
 	}
+
+	if config.PerHostQPS > 0 {
+		c.limiter = newHostLimiter(config.PerHostQPS)
+	}
+	if config.BreakerThreshold > 0 {
+		cooldown := time.Duration(config.BreakerCooldown) * time.Second
+		if cooldown <= 0 {
+			cooldown = 5 * time.Second
+		}
+		c.breaker = newHostBreaker(config.BreakerThreshold, cooldown)
+	}
+	if !config.NoCache {
+		maxSize := config.CacheMaxSize
+		if maxSize <= 0 {
+			maxSize = defaultCacheMaxSize
+		}
+		c.cache = newResponseCache(maxSize, positiveCacheTTL, negativeCacheTTL)
+	}
+	c.positiveTTL = cache.DefaultPositiveTTL
+	if config.CacheTTL > 0 {
+		c.positiveTTL = time.Duration(config.CacheTTL) * time.Second
+	}
+	if config.CachePath != "" && !config.NoCache {
+		diskCache, err := cache.Open(config.CachePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[!] disabling persistent cache: %v\n", err)
+		}
+		c.diskCache = diskCache
+	}
+	if config.PacerRetries > 0 {
+		minSleep := time.Duration(config.PacerMinSleepMS) * time.Millisecond
+		if minSleep <= 0 {
+			minSleep = 10 * time.Millisecond
+		}
+		maxSleep := time.Duration(config.PacerMaxSleepMS) * time.Millisecond
+		if maxSleep <= 0 {
+			maxSleep = 2 * time.Second
+		}
+		c.pacer = NewPacer(minSleep, maxSleep, 2, config.PacerRetries)
+	}
+	if !config.NoRetry {
+		strategy := DefaultRetryStrategy
+		c.retry = &strategy
+	}
+
+	return c
 }
 
 // Check performs a HEAD/GET request to check for existence
-func (c *Client) Check(url string) (int, int64, error) {
-	req := fasthttp.AcquireRequest()
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(resp)
+func (c *Client) Check(target string) (int, int64, error) {
+	cacheKey := "HEAD " + target
+	if cached, ok := c.cache.get(cacheKey); ok {
+		cr := cached.(checkResult)
+		return cr.status, cr.size, nil
+	}
+	if entry, ok := c.diskCache.Get(cache.Key("http", cacheKey)); ok {
+		c.cache.set(cacheKey, checkResult{status: entry.Status, size: entry.Size}, entry.Status == 404)
+		return entry.Status, entry.Size, nil
+	}
 
-	req.SetRequestURI(url)
-	req.Header.SetMethod(fasthttp.MethodHead) // Start with HEAD for speed
+	host := hostOf(target)
 
-	err := c.client.Do(req, resp)
-	if err != nil {
-		return 0, 0, err
+	if !c.breaker.allow(host) {
+		return 0, 0, ErrCircuitOpen
 	}
 
-	return resp.StatusCode(), int64(resp.Header.ContentLength()), nil
+	var status int
+	var size int64
+	var rs *retryState
+	if c.retry != nil {
+		rs = c.retry.start()
+	}
+	for attempt := 0; ; attempt++ {
+		c.limiter.wait(host)
+		c.pacer.Wait(host)
+
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+
+		req.SetRequestURI(target)
+		req.Header.SetMethod(fasthttp.MethodHead) // Start with HEAD for speed
+
+		err := c.client.Do(req, resp)
+		var retryAfter time.Duration
+		if err == nil {
+			status, size = resp.StatusCode(), int64(resp.Header.ContentLength())
+			retryAfter, _ = parseRetryAfter(string(resp.Header.Peek("Retry-After")))
+		}
+		c.recordOutcome(host, status, err)
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+
+		if rs != nil {
+			if (IsThrottled(status, nil) || isRetryableConnError(err)) && rs.shouldRetry(retryAfter) {
+				c.pacer.OnThrottled(host)
+				continue
+			}
+			if err != nil {
+				return 0, 0, err
+			}
+			c.pacer.OnSuccess(host)
+			break
+		}
+
+		if err != nil {
+			return 0, 0, err
+		}
+		if IsThrottled(status, nil) && attempt < c.pacer.Retries() {
+			c.pacer.OnThrottled(host)
+			continue
+		}
+		c.pacer.OnSuccess(host)
+		break
+	}
+
+	c.cache.set(cacheKey, checkResult{status: status, size: size}, status == 404)
+	c.diskCacheSet(cacheKey, status, size)
+
+	return status, size, nil
+}
+
+// diskCacheSet stores key's HTTP status/size in the persistent cache
+// (see pkg/cache), choosing cache.DefaultHTTPNegativeTTL for a 404 and
+// c.positiveTTL otherwise. A nil diskCache is a no-op.
+func (c *Client) diskCacheSet(key string, status int, size int64) {
+	ttl := c.positiveTTL
+	if status == 404 {
+		ttl = cache.DefaultHTTPNegativeTTL
+	}
+	c.diskCache.Set(cache.Key("http", key), cache.Entry{
+		Status:    status,
+		Size:      size,
+		CheckedAt: time.Now(),
+		TTL:       ttl,
+	})
 }
 
 // GetBody performs a GET request and returns the body
-func (c *Client) GetBody(url string) ([]byte, error) {
+func (c *Client) GetBody(target string) ([]byte, error) {
+	cacheKey := "GET " + target
+	if cached, ok := c.cache.get(cacheKey); ok {
+		return cached.([]byte), nil
+	}
+
+	host := hostOf(target)
+
+	if !c.breaker.allow(host) {
+		return nil, ErrCircuitOpen
+	}
+
+	var status int
+	var body []byte
+	var rs *retryState
+	if c.retry != nil {
+		rs = c.retry.start()
+	}
+	for attempt := 0; ; attempt++ {
+		c.limiter.wait(host)
+		c.pacer.Wait(host)
+
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+
+		req.SetRequestURI(target)
+		req.Header.SetMethod(fasthttp.MethodGet)
+
+		err := c.client.Do(req, resp)
+		var retryAfter time.Duration
+		if err == nil {
+			status = resp.StatusCode()
+			// Copy body because ReleaseResponse recycles it
+			body = make([]byte, len(resp.Body()))
+			copy(body, resp.Body())
+			retryAfter, _ = parseRetryAfter(string(resp.Header.Peek("Retry-After")))
+		}
+		c.recordOutcome(host, status, err)
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+
+		if rs != nil {
+			if (IsThrottled(status, body) || isRetryableConnError(err)) && rs.shouldRetry(retryAfter) {
+				c.pacer.OnThrottled(host)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			c.pacer.OnSuccess(host)
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if IsThrottled(status, body) && attempt < c.pacer.Retries() {
+			c.pacer.OnThrottled(host)
+			continue
+		}
+		c.pacer.OnSuccess(host)
+		break
+	}
+
+	c.cache.set(cacheKey, body, status == 404)
+
+	return body, nil
+}
+
+// ambiguousStatuses are the Check results a HEAD's empty body can't
+// disambiguate on its own - AccessDenied vs. a WAF challenge vs. a
+// genuine NoSuchBucket all show up as one of these - so CheckWithBody
+// promotes to a GET only for these, not every status.
+var ambiguousStatuses = map[int]bool{400: true, 403: true, 404: true, 503: true}
+
+// CheckWithBody behaves like Check but also returns up to maxBodyBytes
+// of the response body for a pkg/fingerprint.Classify call to work
+// with, fetching it via a GET only when status is one of
+// ambiguousStatuses - a clean 200/204/301/307 returns a nil body
+// without the extra request. A failed follow-up GET still returns
+// status/size from the original Check; only the body is missing.
+func (c *Client) CheckWithBody(target string, maxBodyBytes int) (int, int64, []byte, error) {
+	status, size, err := c.Check(target)
+	if err != nil || !ambiguousStatuses[status] {
+		return status, size, nil, err
+	}
+
+	body, err := c.GetBody(target)
+	if err != nil {
+		return status, size, nil, nil
+	}
+	if maxBodyBytes > 0 && len(body) > maxBodyBytes {
+		body = body[:maxBodyBytes]
+	}
+	return status, size, body, nil
+}
+
+// CacheStats returns the shared response cache's cumulative hit/miss
+// counts, or (0, 0) if caching is disabled (-no-cache).
+func (c *Client) CacheStats() (hits, misses int64) {
+	return c.cache.stats()
+}
+
+// Close closes the persistent cache opened for Config.CachePath, if
+// any. Safe to call even when CachePath was never set.
+func (c *Client) Close() error {
+	return c.diskCache.Close()
+}
+
+// GetBodySigned performs a GET with extra request headers attached
+// (e.g. a SigV4 Authorization header) and returns the body and status.
+// A thin wrapper around SignedDo for the common case (GET, no body).
+func (c *Client) GetBodySigned(target string, headers map[string]string) ([]byte, int, error) {
+	return c.SignedDo(fasthttp.MethodGet, target, headers, nil)
+}
+
+// SignedDo performs a method request against target with extra request
+// headers attached (e.g. a SigV4 Authorization/x-amz-content-sha256 set
+// from pkg/providers/aws.signRequest) and an optional body, returning the
+// response body and status. Signed requests bypass the response cache:
+// their validity is time-bound (a date-stamped signature, or a
+// write/delete with side effects), so a cache hit would replay something
+// that's no longer valid or re-issue a mutation that already happened.
+func (c *Client) SignedDo(method, target string, headers map[string]string, body []byte) ([]byte, int, error) {
+	host := hostOf(target)
+
+	if !c.breaker.allow(host) {
+		return nil, 0, ErrCircuitOpen
+	}
+	c.limiter.wait(host)
+
 	req := fasthttp.AcquireRequest()
 	resp := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseRequest(req)
 	defer fasthttp.ReleaseResponse(resp)
 
-	req.SetRequestURI(url)
-	req.Header.SetMethod(fasthttp.MethodGet)
+	req.SetRequestURI(target)
+	req.Header.SetMethod(method)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if len(body) > 0 {
+		req.SetBody(body)
+	}
 
 	err := c.client.Do(req, resp)
+	c.recordOutcome(host, resp.StatusCode(), err)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Copy body because ReleaseResponse recycles it
-	body := make([]byte, len(resp.Body()))
-	copy(body, resp.Body())
+	respBody := make([]byte, len(resp.Body()))
+	copy(respBody, resp.Body())
 
-	return body, nil
+	return respBody, resp.StatusCode(), nil
+}
+
+// Pace blocks for host's current rate-limit and pacer backoff, for
+// callers that issue a request outside Check/GetBody (e.g.
+// aws.S3ProviderV2.detectRegion's raw http.Head) but still want to
+// share this client's per-host throttling state.
+func (c *Client) Pace(host string) {
+	c.limiter.wait(host)
+	c.pacer.Wait(host)
+}
+
+// RecordPacerOutcome feeds a throttled/not-throttled verdict back into
+// the pacer for host, for callers using Pace directly.
+func (c *Client) RecordPacerOutcome(host string, throttled bool) {
+	if throttled {
+		c.pacer.OnThrottled(host)
+		return
+	}
+	c.pacer.OnSuccess(host)
+}
+
+// recordOutcome feeds the breaker: connection errors and 5xx status
+// codes count as failures, everything else closes the failure streak.
+func (c *Client) recordOutcome(host string, status int, err error) {
+	if err != nil || status >= 500 {
+		c.breaker.recordFailure(host)
+		return
+	}
+	c.breaker.recordSuccess(host)
+}
+
+func hostOf(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	return u.Host
 }
@@ -2,28 +2,55 @@ package net
 
 import (
 	"context"
-	"net"
+	"errors"
+	"fmt"
+	"os"
+	"skyscan/pkg/cache"
+	"skyscan/pkg/core"
 	"sync"
 	"time"
 )
 
 // DNSResolver provides high-performance DNS resolution for bucket enumeration.
 // DNS-based enumeration is stealthier than HTTP HEAD requests as it:
-// 1. Doesn't trigger S3 access logging
-// 2. Uses small UDP packets (faster than TCP/TLS handshakes)
-// 3. Can check existence without authentication
+//  1. Doesn't trigger S3 access logging
+//  2. Can use transports (DoH, DoQ) that blend in with or bypass
+//     captive-portal/enterprise DNS interception, unlike plain UDP/53
+//  3. Can check existence without authentication
 type DNSResolver struct {
-	resolvers []string
-	timeout   time.Duration
-	mu        sync.RWMutex
-	cache     map[string]bool // Simple cache for resolved domains
+	transports []Transport
+	timeout    time.Duration
+	cache      *responseCache // shared with net.Client's TTL/LRU cache design
+
+	// diskCache is the persistent counterpart to cache above (see
+	// pkg/cache) - nil when Config.CachePath is empty or Config.NoCache
+	// is set. positiveTTL is the TTL a fresh positive result is stored
+	// with; negatives always use cache.DefaultDNSNegativeTTL.
+	diskCache   *cache.Store
+	positiveTTL time.Duration
+
+	// wildcards holds each zone's wildcard-DNS fingerprint (see
+	// dns_wildcard.go), and wildcardOnce makes sure DetectWildcards
+	// only probes a given zone once per resolver.
+	wildcardsMu    sync.RWMutex
+	wildcards      map[string]*wildcardFingerprint
+	wildcardOnceMu sync.Mutex
+	wildcardOnce   map[string]*sync.Once
 }
 
-// NewDNSResolver creates a resolver with custom DNS servers
-func NewDNSResolver(resolvers []string, timeout int) *DNSResolver {
-	if len(resolvers) == 0 {
-		// Default to fast public resolvers
-		resolvers = []string{
+// NewDNSResolver creates a resolver over config.Resolvers, a list of
+// resolver URLs dispatched per NewTransport's scheme rules (a bare
+// "host:port" is treated as "udp://host:port" for backward
+// compatibility). Resolvers are rotated round-robin per lookup, so a
+// mix of schemes (e.g. "udp://8.8.8.8:53,https://1.1.1.1/dns-query")
+// spreads queries across transports in the same scan. A resolver URL
+// that fails to parse is logged and skipped rather than failing the
+// whole scan.
+func NewDNSResolver(config *core.Config) *DNSResolver {
+	resolverURLs := config.Resolvers
+	if len(resolverURLs) == 0 {
+		// Default to fast public UDP resolvers.
+		resolverURLs = []string{
 			"8.8.8.8:53",        // Google
 			"1.1.1.1:53",        // Cloudflare
 			"9.9.9.9:53",        // Quad9
@@ -31,10 +58,44 @@ func NewDNSResolver(resolvers []string, timeout int) *DNSResolver {
 		}
 	}
 
+	var transports []Transport
+	for _, resolverURL := range resolverURLs {
+		transport, err := NewTransport(resolverURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[!] skipping resolver %q: %v\n", resolverURL, err)
+			continue
+		}
+		transports = append(transports, transport)
+	}
+
+	var respCache *responseCache
+	if !config.NoCache {
+		maxSize := config.CacheMaxSize
+		if maxSize <= 0 {
+			maxSize = defaultCacheMaxSize
+		}
+		respCache = newResponseCache(maxSize, positiveCacheTTL, negativeCacheTTL)
+	}
+
+	var diskCache *cache.Store
+	positiveTTL := cache.DefaultPositiveTTL
+	if config.CacheTTL > 0 {
+		positiveTTL = time.Duration(config.CacheTTL) * time.Second
+	}
+	if config.CachePath != "" && !config.NoCache {
+		var err error
+		diskCache, err = cache.Open(config.CachePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[!] disabling persistent cache: %v\n", err)
+		}
+	}
+
 	return &DNSResolver{
-		resolvers: resolvers,
-		timeout:   time.Duration(timeout) * time.Second,
-		cache:     make(map[string]bool),
+		transports:  transports,
+		timeout:     time.Duration(config.Timeout) * time.Second,
+		cache:       respCache,
+		diskCache:   diskCache,
+		positiveTTL: positiveTTL,
 	}
 }
 
@@ -45,38 +106,37 @@ type DNSResult struct {
 	IPs     []string
 	Error   error
 	Latency time.Duration
+
+	// Wildcard is set when domain resolved but its IPs matched its
+	// zone's wildcard-DNS fingerprint (see dns_wildcard.go) - Exists is
+	// forced to false alongside it, since the zone answers for every
+	// label and the positive carries no information.
+	Wildcard bool
 }
 
 // CheckExists performs a DNS lookup to check if a domain exists
 // Returns true if the domain resolves (bucket exists), false if NXDOMAIN
 func (r *DNSResolver) CheckExists(ctx context.Context, domain string) *DNSResult {
-	// Check cache first
-	r.mu.RLock()
-	if exists, ok := r.cache[domain]; ok {
-		r.mu.RUnlock()
+	// Check the in-memory cache first, then the persistent one - a hit
+	// on either short-circuits the actual lookup.
+	if cached, ok := r.cache.get(domain); ok {
+		return &DNSResult{Domain: domain, Exists: cached.(bool)}
+	}
+	if entry, ok := r.diskCache.Get(cache.Key("dns", domain)); ok {
+		exists := entry.Status == 1
+		r.cache.set(domain, exists, !exists)
 		return &DNSResult{Domain: domain, Exists: exists}
 	}
-	r.mu.RUnlock()
 
-	start := time.Now()
+	zone := zoneOf(domain)
+	r.ensureWildcardDetection(ctx, zone)
 
-	// Create resolver with custom DNS server
-	resolver := &net.Resolver{
-		PreferGo: true,
-		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-			d := net.Dialer{Timeout: r.timeout}
-			// Rotate through resolvers
-			server := r.resolvers[time.Now().UnixNano()%int64(len(r.resolvers))]
-			return d.DialContext(ctx, "udp", server)
-		},
-	}
+	start := time.Now()
 
-	// Set context timeout
-	ctx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
+	// Rotate through resolvers (and therefore transports)
+	transport := r.transports[time.Now().UnixNano()%int64(len(r.transports))]
 
-	// Perform lookup
-	ips, err := resolver.LookupHost(ctx, domain)
+	ips, err := transport.LookupHost(ctx, r.timeout, domain)
 	latency := time.Since(start)
 
 	result := &DNSResult{
@@ -85,33 +145,49 @@ func (r *DNSResolver) CheckExists(ctx context.Context, domain string) *DNSResult
 	}
 
 	if err != nil {
-		// Check if it's NXDOMAIN (doesn't exist) vs other error
-		if dnsErr, ok := err.(*net.DNSError); ok {
-			if dnsErr.IsNotFound {
-				result.Exists = false
-				// Cache negative result
-				r.mu.Lock()
-				r.cache[domain] = false
-				r.mu.Unlock()
-				return result
-			}
+		if errors.Is(err, ErrNXDomain) {
+			result.Exists = false
+			r.cache.set(domain, false, true)
+			r.diskCacheSet(domain, false)
+			return result
 		}
 		result.Error = err
 		return result
 	}
 
+	if r.isWildcardMatch(zone, ips) {
+		result.Exists = false
+		result.Wildcard = true
+		result.IPs = ips
+		r.cache.set(domain, false, true)
+		r.diskCacheSet(domain, false)
+		return result
+	}
+
 	// Domain resolves - bucket exists
 	result.Exists = true
 	result.IPs = ips
-
-	// Cache positive result
-	r.mu.Lock()
-	r.cache[domain] = true
-	r.mu.Unlock()
+	r.cache.set(domain, true, false)
+	r.diskCacheSet(domain, true)
 
 	return result
 }
 
+// diskCacheSet stores domain's exists verdict in the persistent cache
+// (see pkg/cache), choosing DefaultDNSNegativeTTL for a negative result
+// and r.positiveTTL for a positive one. A nil diskCache is a no-op.
+func (r *DNSResolver) diskCacheSet(domain string, exists bool) {
+	status, ttl := 0, cache.DefaultDNSNegativeTTL
+	if exists {
+		status, ttl = 1, r.positiveTTL
+	}
+	r.diskCache.Set(cache.Key("dns", domain), cache.Entry{
+		Status:    status,
+		CheckedAt: time.Now(),
+		TTL:       ttl,
+	})
+}
+
 // BatchCheck performs concurrent DNS lookups for multiple domains
 func (r *DNSResolver) BatchCheck(ctx context.Context, domains []string, concurrency int) []*DNSResult {
 	results := make([]*DNSResult, len(domains))
@@ -142,7 +218,17 @@ func (r *DNSResolver) BatchCheck(ctx context.Context, domains []string, concurre
 
 // ClearCache clears the DNS resolution cache
 func (r *DNSResolver) ClearCache() {
-	r.mu.Lock()
-	r.cache = make(map[string]bool)
-	r.mu.Unlock()
+	r.cache.clear()
+}
+
+// CacheStats returns the shared response cache's cumulative hit/miss
+// counts, or (0, 0) if caching is disabled (-no-cache).
+func (r *DNSResolver) CacheStats() (hits, misses int64) {
+	return r.cache.stats()
+}
+
+// Close closes the persistent cache opened for Config.CachePath, if
+// any. Safe to call even when CachePath was never set.
+func (r *DNSResolver) Close() error {
+	return r.diskCache.Close()
 }
@@ -0,0 +1,173 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DoHTransport resolves over DNS-over-HTTPS (RFC 8484), POSTing a raw
+// DNS wire-format query to endpoint (e.g. "https://1.1.1.1/dns-query",
+// "https://cloudflare-dns.com/dns-query") with the standard
+// application/dns-message content type every public DoH resolver
+// accepts, sidestepping the need to special-case each provider's JSON
+// API dialect.
+type DoHTransport struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewDoHTransport(endpoint string) *DoHTransport {
+	return &DoHTransport{
+		endpoint: endpoint,
+		client:   &http.Client{},
+	}
+}
+
+func (t *DoHTransport) LookupHost(ctx context.Context, timeout time.Duration, domain string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query, id := buildDNSQuery(domain)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	client := t.client
+	client.Timeout = timeout
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDNSResponse(body, id)
+}
+
+// buildDNSQuery encodes a minimal single-question A-record query for
+// domain, returning the wire-format message and the transaction ID it
+// embeds (so the caller can match it against the response).
+func buildDNSQuery(domain string) ([]byte, uint16) {
+	id := uint16(rand.Intn(1 << 16))
+
+	var buf bytes.Buffer
+	// Header: ID, flags (RD=1, everything else 0), QDCOUNT=1, rest 0.
+	binary.Write(&buf, binary.BigEndian, id)
+	buf.Write([]byte{0x01, 0x00}) // flags: recursion desired
+	buf.Write([]byte{0x00, 0x01}) // QDCOUNT
+	buf.Write([]byte{0x00, 0x00}) // ANCOUNT
+	buf.Write([]byte{0x00, 0x00}) // NSCOUNT
+	buf.Write([]byte{0x00, 0x00}) // ARCOUNT
+
+	for _, label := range strings.Split(strings.TrimSuffix(domain, "."), ".") {
+		buf.WriteByte(byte(len(label)))
+		buf.WriteString(label)
+	}
+	buf.WriteByte(0x00)           // root label
+	buf.Write([]byte{0x00, 0x01}) // QTYPE A
+	buf.Write([]byte{0x00, 0x01}) // QCLASS IN
+
+	return buf.Bytes(), id
+}
+
+// parseDNSResponse extracts the A-record IPs from a wire-format DNS
+// response, returning ErrNXDomain if the server set RCODE 3 (NXDOMAIN).
+func parseDNSResponse(data []byte, wantID uint16) ([]string, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("dns response too short (%d bytes)", len(data))
+	}
+
+	id := binary.BigEndian.Uint16(data[0:2])
+	if id != wantID {
+		return nil, fmt.Errorf("dns response id mismatch")
+	}
+
+	rcode := data[3] & 0x0F
+	if rcode == 3 {
+		return nil, ErrNXDomain
+	}
+	if rcode != 0 {
+		return nil, fmt.Errorf("dns response rcode %d", rcode)
+	}
+
+	qdcount := int(binary.BigEndian.Uint16(data[4:6]))
+	ancount := int(binary.BigEndian.Uint16(data[6:8]))
+
+	offset := 12
+	for i := 0; i < qdcount; i++ {
+		var err error
+		offset, err = skipDNSName(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset += 4 // QTYPE + QCLASS
+	}
+
+	var ips []string
+	for i := 0; i < ancount; i++ {
+		var err error
+		offset, err = skipDNSName(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		if offset+10 > len(data) {
+			return nil, fmt.Errorf("dns response truncated in answer record")
+		}
+		rtype := binary.BigEndian.Uint16(data[offset : offset+2])
+		rdlength := int(binary.BigEndian.Uint16(data[offset+8 : offset+10]))
+		offset += 10
+
+		if offset+rdlength > len(data) {
+			return nil, fmt.Errorf("dns response truncated in rdata")
+		}
+		if rtype == 1 && rdlength == 4 { // A record
+			ip := data[offset : offset+4]
+			ips = append(ips, fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3]))
+		}
+		offset += rdlength
+	}
+
+	if len(ips) == 0 {
+		return nil, ErrNXDomain
+	}
+	return ips, nil
+}
+
+// skipDNSName advances past a (possibly compressed) name starting at
+// offset and returns the offset immediately following it.
+func skipDNSName(data []byte, offset int) (int, error) {
+	for {
+		if offset >= len(data) {
+			return 0, fmt.Errorf("dns name runs past end of message")
+		}
+		length := int(data[offset])
+
+		switch {
+		case length == 0:
+			return offset + 1, nil
+		case length&0xC0 == 0xC0: // compression pointer
+			if offset+2 > len(data) {
+				return 0, fmt.Errorf("truncated dns name pointer")
+			}
+			return offset + 2, nil
+		default:
+			offset += 1 + length
+		}
+	}
+}
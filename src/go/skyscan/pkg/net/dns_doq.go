@@ -0,0 +1,63 @@
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+// DoQTransport resolves over DNS-over-QUIC (RFC 9250): one query per
+// QUIC stream, each message length-prefixed exactly like DoT/RFC 7766,
+// over a 0-RTT-capable transport that - unlike DoH - doesn't look like
+// ordinary HTTPS traffic to a middlebox inspecting ALPN.
+type DoQTransport struct {
+	addr string
+}
+
+func (t *DoQTransport) LookupHost(ctx context.Context, timeout time.Duration, domain string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := quic.DialAddr(ctx, t.addr, &tls.Config{NextProtos: []string{"doq"}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("doq dial %s: %w", t.addr, err)
+	}
+	defer conn.CloseWithError(0, "")
+
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, id := buildDNSQuery(domain)
+
+	var framed []byte
+	framed = binary.BigEndian.AppendUint16(framed, uint16(len(query)))
+	framed = append(framed, query...)
+	if _, err := stream.Write(framed); err != nil {
+		return nil, err
+	}
+	// RFC 9250 requires the client to signal it has no more data on
+	// this stream (a clean FIN, not a reset) before the server replies.
+	if err := stream.Close(); err != nil {
+		return nil, err
+	}
+
+	lengthPrefix := make([]byte, 2)
+	if _, err := io.ReadFull(stream, lengthPrefix); err != nil {
+		return nil, err
+	}
+	respLen := binary.BigEndian.Uint16(lengthPrefix)
+
+	resp := make([]byte, respLen)
+	if _, err := io.ReadFull(stream, resp); err != nil {
+		return nil, err
+	}
+
+	return parseDNSResponse(resp, id)
+}
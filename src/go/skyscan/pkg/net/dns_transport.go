@@ -0,0 +1,80 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// ErrNXDomain is returned by a Transport when the name conclusively
+// does not exist (as opposed to a timeout or transport-level failure,
+// which CheckExists must not treat as a negative result).
+var ErrNXDomain = errors.New("nxdomain")
+
+// Transport resolves a hostname to its A/AAAA records over one DNS
+// wire protocol. DNSResolver dispatches to a Transport per resolver URL
+// so a single scan can mix plain UDP/53, DoH, and DoQ resolvers.
+type Transport interface {
+	LookupHost(ctx context.Context, timeout time.Duration, domain string) ([]string, error)
+}
+
+// NewTransport builds the Transport a resolver URL describes:
+//   - "udp://8.8.8.8:53" (or a bare "8.8.8.8:53", for backward
+//     compatibility with Config.Resolvers entries predating this
+//     scheme) -> UDPTransport
+//   - "https://1.1.1.1/dns-query" -> DoHTransport (RFC 8484 wire-format
+//     over POST for every endpoint; the Google/Cloudflare JSON API is
+//     not used)
+//   - "quic://dns.adguard.com:8853" -> DoQTransport (RFC 9250)
+func NewTransport(resolverURL string) (Transport, error) {
+	scheme, rest, ok := strings.Cut(resolverURL, "://")
+	if !ok {
+		// No scheme: treat the whole string as a plain "host:port" UDP
+		// resolver, the format Config.Resolvers used before DoH/DoQ.
+		return &UDPTransport{addr: resolverURL}, nil
+	}
+
+	switch scheme {
+	case "udp":
+		return &UDPTransport{addr: rest}, nil
+	case "https":
+		return NewDoHTransport(resolverURL), nil
+	case "quic":
+		addr := rest
+		if !strings.Contains(addr, ":") {
+			addr = net.JoinHostPort(addr, "853")
+		}
+		return &DoQTransport{addr: addr}, nil
+	default:
+		return nil, fmt.Errorf("unsupported resolver scheme %q in %q", scheme, resolverURL)
+	}
+}
+
+// UDPTransport is the original plain DNS-over-UDP/53 behavior, via
+// Go's own resolver (net.Resolver.PreferGo) dialing addr directly.
+type UDPTransport struct {
+	addr string
+}
+
+func (t *UDPTransport) LookupHost(ctx context.Context, timeout time.Duration, domain string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			d := net.Dialer{Timeout: timeout}
+			return d.DialContext(ctx, "udp", t.addr)
+		},
+	}
+
+	ips, err := resolver.LookupHost(ctx, domain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, ErrNXDomain
+		}
+		return nil, err
+	}
+	return ips, nil
+}
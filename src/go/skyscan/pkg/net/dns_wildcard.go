@@ -0,0 +1,178 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// wildcardProbeLabels is how many random labels DetectWildcards
+// resolves under a zone before deciding whether it's a wildcard.
+// wildcardProbeLabelLen is how long each one is - long enough that a
+// real bucket/app name colliding with it is astronomically unlikely.
+const (
+	wildcardProbeLabels   = 5
+	wildcardProbeLabelLen = 20
+
+	// wildcardAgreementThreshold is how many of the probes must resolve
+	// to the same IP set before that set is considered the zone's
+	// wildcard fingerprint, rather than a coincidence.
+	wildcardAgreementThreshold = 2
+
+	// wildcardFingerprintTTL bounds how long a fingerprint is trusted -
+	// a wildcard's target IPs can change (e.g. a reassigned LB VIP).
+	// Once it expires the fingerprint is no longer applied; the zone is
+	// not probed again (see ensureWildcardDetection).
+	wildcardFingerprintTTL = 1 * time.Hour
+)
+
+// wildcardFingerprint is the IP set a zone's DNS resolves arbitrary
+// labels to, and when it was captured.
+type wildcardFingerprint struct {
+	ips        map[string]bool
+	capturedAt time.Time
+}
+
+func (f *wildcardFingerprint) expired() bool {
+	return time.Since(f.capturedAt) > wildcardFingerprintTTL
+}
+
+// zoneOf returns the zone CheckExists/DetectWildcards key wildcard
+// fingerprints under: domain with its leftmost label stripped, e.g.
+// "mybucket.s3.amazonaws.com" -> "s3.amazonaws.com". This matches how
+// every CloudAssetEnumerator/provider DomainPattern puts the candidate
+// name in the leftmost label of a fixed parent zone.
+func zoneOf(domain string) string {
+	_, zone, ok := strings.Cut(domain, ".")
+	if !ok {
+		return domain
+	}
+	return zone
+}
+
+// randomLabel returns an n-character lowercase-alphanumeric label
+// vanishingly unlikely to collide with a real bucket/app name.
+func randomLabel(n int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// ipSetKey returns a stable, order-independent key for an IP set, so
+// two lookups that returned the same IPs in a different order still
+// compare equal.
+func ipSetKey(ips []string) string {
+	sorted := append([]string(nil), ips...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// DetectWildcards probes zone with wildcardProbeLabels random,
+// never-registered labels. If at least wildcardAgreementThreshold of
+// them resolve to the same non-empty IP set, that set is cached as
+// zone's wildcard fingerprint (see wildcardFingerprintTTL): a
+// subsequent CheckExists for any domain under zone whose resolved IPs
+// are a subset of the fingerprint is downgraded to Exists: false /
+// Wildcard: true, since it was never a real positive - just the zone
+// answering for everything.
+func (r *DNSResolver) DetectWildcards(ctx context.Context, zone string) error {
+	if len(r.transports) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	keyToIPs := make(map[string][]string)
+
+	for i := 0; i < wildcardProbeLabels; i++ {
+		probe := fmt.Sprintf("%s.%s", randomLabel(wildcardProbeLabelLen), zone)
+		transport := r.transports[time.Now().UnixNano()%int64(len(r.transports))]
+
+		ips, err := transport.LookupHost(ctx, r.timeout, probe)
+		if err != nil || len(ips) == 0 {
+			continue
+		}
+
+		key := ipSetKey(ips)
+		counts[key]++
+		keyToIPs[key] = ips
+	}
+
+	for key, count := range counts {
+		if count >= wildcardAgreementThreshold {
+			r.setWildcardFingerprint(zone, keyToIPs[key])
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *DNSResolver) setWildcardFingerprint(zone string, ips []string) {
+	r.wildcardsMu.Lock()
+	defer r.wildcardsMu.Unlock()
+
+	if r.wildcards == nil {
+		r.wildcards = make(map[string]*wildcardFingerprint)
+	}
+
+	set := make(map[string]bool, len(ips))
+	for _, ip := range ips {
+		set[ip] = true
+	}
+	r.wildcards[zone] = &wildcardFingerprint{ips: set, capturedAt: time.Now()}
+}
+
+// wildcardFingerprintFor returns zone's cached fingerprint, or nil if
+// none is cached or it has expired.
+func (r *DNSResolver) wildcardFingerprintFor(zone string) *wildcardFingerprint {
+	r.wildcardsMu.RLock()
+	defer r.wildcardsMu.RUnlock()
+
+	fp, ok := r.wildcards[zone]
+	if !ok || fp.expired() {
+		return nil
+	}
+	return fp
+}
+
+// isWildcardMatch reports whether ips is a (non-empty) subset of
+// zone's known wildcard fingerprint.
+func (r *DNSResolver) isWildcardMatch(zone string, ips []string) bool {
+	fp := r.wildcardFingerprintFor(zone)
+	if fp == nil || len(ips) == 0 {
+		return false
+	}
+	for _, ip := range ips {
+		if !fp.ips[ip] {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureWildcardDetection runs DetectWildcards for zone at most once
+// per DNSResolver, the first time CheckExists sees a domain under it -
+// in effect "once at scan start per provider zone" without requiring
+// every core.Provider.Init to grow a context parameter.
+func (r *DNSResolver) ensureWildcardDetection(ctx context.Context, zone string) {
+	r.wildcardOnceMu.Lock()
+	once, ok := r.wildcardOnce[zone]
+	if !ok {
+		once = &sync.Once{}
+		if r.wildcardOnce == nil {
+			r.wildcardOnce = make(map[string]*sync.Once)
+		}
+		r.wildcardOnce[zone] = once
+	}
+	r.wildcardOnceMu.Unlock()
+
+	once.Do(func() {
+		r.DetectWildcards(ctx, zone)
+	})
+}
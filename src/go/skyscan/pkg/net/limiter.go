@@ -0,0 +1,268 @@
+package net
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// multiPartSuffixes holds the common two-label public suffixes the
+// naive eTLD+1 split below would otherwise cut in the wrong place
+// (e.g. "foo.co.uk" must stay "foo.co.uk", not become "co.uk").
+var multiPartSuffixes = map[string]bool{
+	"co.uk": true, "org.uk": true, "ac.uk": true,
+	"co.jp": true, "com.au": true, "com.br": true,
+	"co.nz": true, "co.in": true, "com.cn": true,
+}
+
+// etldPlusOne returns a reasonable eTLD+1 for host, used to key the
+// per-host limiter and breaker (e.g. "bucket.s3.amazonaws.com" ->
+// "amazonaws.com"). It's a pragmatic approximation, not a full public
+// suffix list lookup.
+func etldPlusOne(host string) string {
+	host = strings.ToLower(host)
+	if i := strings.IndexByte(host, ':'); i != -1 {
+		host = host[:i]
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+
+	last2 := strings.Join(labels[len(labels)-2:], ".")
+	if multiPartSuffixes[last2] && len(labels) >= 3 {
+		return strings.Join(labels[len(labels)-3:], ".")
+	}
+	return last2
+}
+
+// tokenBucket is a simple rate limiter: it refills at qps tokens/second
+// up to burst tokens.
+type tokenBucket struct {
+	mu       sync.Mutex
+	qps      float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+// newTokenBucket returns a bucket with a burst of 1 second's worth of
+// requests (qps), the behavior hostLimiter has always had.
+func newTokenBucket(qps float64) *tokenBucket {
+	return newTokenBucketWithBurst(qps, qps)
+}
+
+func newTokenBucketWithBurst(qps, burst float64) *tokenBucket {
+	return &tokenBucket{
+		qps:      qps,
+		burst:    burst,
+		tokens:   burst,
+		lastFill: time.Now(),
+	}
+}
+
+// setQPS changes the bucket's refill rate, clamping its current token
+// balance to the new ceiling so a rate cut takes effect immediately
+// instead of draining a stale, larger balance first. burst is
+// unaffected.
+func (b *tokenBucket) setQPS(qps float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.qps = qps
+	if b.tokens > qps {
+		b.tokens = qps
+	}
+}
+
+// wait blocks until a token is available for this host.
+func (b *tokenBucket) wait() {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(b.lastFill).Seconds()
+		b.lastFill = now
+		b.tokens += elapsed * b.qps
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return
+		}
+
+		deficit := 1 - b.tokens
+		b.mu.Unlock()
+		time.Sleep(time.Duration(deficit/b.qps*float64(time.Second)) + time.Millisecond)
+	}
+}
+
+// hostLimiter keys a tokenBucket per eTLD+1 so unrelated hosts never
+// throttle each other.
+type hostLimiter struct {
+	qps     float64
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newHostLimiter(qps float64) *hostLimiter {
+	return &hostLimiter{
+		qps:     qps,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// wait blocks until host is allowed to issue another request. A nil
+// receiver or a non-positive qps disables limiting entirely.
+func (l *hostLimiter) wait(host string) {
+	if l == nil || l.qps <= 0 {
+		return
+	}
+
+	key := etldPlusOne(host)
+
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(l.qps)
+		l.buckets[key] = b
+	}
+	l.mu.Unlock()
+
+	b.wait()
+}
+
+// throttleStreakToHalve/healthyStreakToRestore are how many consecutive
+// throttled or clean results ProviderLimiter requires before it adjusts
+// a provider's effective rate - single-sample noise (one stray 503)
+// shouldn't move the rate, only a sustained run should.
+const (
+	throttleStreakToHalve         = 3
+	healthyStreakToRestore        = 20
+	providerMinRateFractionOfBase = 0.05
+)
+
+// providerRateState is one provider's current effective rate and the
+// consecutive-result streak ProviderLimiter uses to decide whether to
+// adjust it.
+type providerRateState struct {
+	mu             sync.Mutex
+	bucket         *tokenBucket
+	effectiveQPS   float64
+	throttleStreak int
+	healthyStreak  int
+}
+
+// ProviderLimiter is a token-bucket rate limiter keyed by provider name
+// (e.g. "S3", "Azure Blob", "GCS") rather than hostname, so
+// engine.FullCloudRecon's worker loops can cap and adapt throughput
+// per cloud provider instead of per eTLD+1 (pkg/net's hostLimiter/Pacer
+// already cover the host-level case for a single net.Client). On a
+// sustained run of throttled responses (see IsThrottled) a provider's
+// effective rate is halved; on a sustained healthy run it's restored
+// back toward base, one doubling at a time.
+type ProviderLimiter struct {
+	base  float64 // requests/sec per provider before any backoff
+	burst float64
+
+	mu     sync.Mutex
+	states map[string]*providerRateState
+}
+
+// NewProviderLimiter returns a limiter capping every provider at qps
+// requests/sec with a burst of burst tokens. A non-positive qps
+// disables limiting (Wait/ReportResult become no-ops); burst <= 0
+// defaults to qps (1 second's worth of burst).
+func NewProviderLimiter(qps float64, burst int) *ProviderLimiter {
+	b := float64(burst)
+	if b <= 0 {
+		b = qps
+	}
+	return &ProviderLimiter{
+		base:   qps,
+		burst:  b,
+		states: make(map[string]*providerRateState),
+	}
+}
+
+func (l *ProviderLimiter) stateFor(provider string) *providerRateState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st, ok := l.states[provider]
+	if !ok {
+		st = &providerRateState{
+			bucket:       newTokenBucketWithBurst(l.base, l.burst),
+			effectiveQPS: l.base,
+		}
+		l.states[provider] = st
+	}
+	return st
+}
+
+// Wait blocks until provider is allowed to issue another request. A
+// nil receiver or a non-positive base rate disables limiting entirely.
+func (l *ProviderLimiter) Wait(provider string) {
+	if l == nil || l.base <= 0 {
+		return
+	}
+	l.stateFor(provider).bucket.wait()
+}
+
+// ReportResult feeds a request's outcome back into provider's rate:
+// throttleStreakToHalve consecutive throttled responses halve the
+// effective rate (floored at providerMinRateFractionOfBase of base);
+// healthyStreakToRestore consecutive clean responses double it back,
+// capped at base. A nil receiver is a no-op.
+func (l *ProviderLimiter) ReportResult(provider string, throttled bool) {
+	if l == nil || l.base <= 0 {
+		return
+	}
+
+	st := l.stateFor(provider)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if throttled {
+		st.healthyStreak = 0
+		st.throttleStreak++
+		if st.throttleStreak >= throttleStreakToHalve {
+			st.throttleStreak = 0
+			floor := l.base * providerMinRateFractionOfBase
+			st.effectiveQPS /= 2
+			if st.effectiveQPS < floor {
+				st.effectiveQPS = floor
+			}
+			st.bucket.setQPS(st.effectiveQPS)
+		}
+		return
+	}
+
+	st.throttleStreak = 0
+	st.healthyStreak++
+	if st.healthyStreak >= healthyStreakToRestore {
+		st.healthyStreak = 0
+		st.effectiveQPS *= 2
+		if st.effectiveQPS > l.base {
+			st.effectiveQPS = l.base
+		}
+		st.bucket.setQPS(st.effectiveQPS)
+	}
+}
+
+// EffectiveRate returns provider's current effective requests/sec, or
+// base if provider hasn't issued a request yet.
+func (l *ProviderLimiter) EffectiveRate(provider string) float64 {
+	if l == nil {
+		return 0
+	}
+	if l.base <= 0 {
+		return 0
+	}
+	st := l.stateFor(provider)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.effectiveQPS
+}
@@ -0,0 +1,137 @@
+package net
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// pacerState tracks one host's current backoff sleep.
+type pacerState struct {
+	mu    sync.Mutex
+	sleep time.Duration
+}
+
+// Pacer implements an adaptive per-host backoff modeled on rclone's
+// lib/pacer: unlike hostLimiter's fixed QPS cap, it reacts to what the
+// remote actually says. Every throttling response (429/503, or an S3
+// SlowDown/ServiceUnavailable XML <Code>) doubles that host's sleep up
+// to MaxSleep; every success decays it back down by DecayConstant,
+// floored at MinSleep.
+type Pacer struct {
+	minSleep      time.Duration
+	maxSleep      time.Duration
+	decayConstant float64
+	retries       int
+
+	mu     sync.Mutex
+	states map[string]*pacerState
+}
+
+// NewPacer returns a Pacer starting every host unthrottled at
+// minSleep. decayConstant must be > 1; retries is how many times a
+// caller should retry a single throttled request before giving up.
+func NewPacer(minSleep, maxSleep time.Duration, decayConstant float64, retries int) *Pacer {
+	if decayConstant <= 1 {
+		decayConstant = 2
+	}
+	return &Pacer{
+		minSleep:      minSleep,
+		maxSleep:      maxSleep,
+		decayConstant: decayConstant,
+		retries:       retries,
+		states:        make(map[string]*pacerState),
+	}
+}
+
+func (p *Pacer) stateFor(host string) *pacerState {
+	key := etldPlusOne(host)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	st, ok := p.states[key]
+	if !ok {
+		st = &pacerState{sleep: p.minSleep}
+		p.states[key] = st
+	}
+	return st
+}
+
+// Wait blocks for host's current backoff sleep. A nil receiver
+// disables pacing entirely.
+func (p *Pacer) Wait(host string) {
+	if p == nil {
+		return
+	}
+
+	st := p.stateFor(host)
+	st.mu.Lock()
+	sleep := st.sleep
+	st.mu.Unlock()
+
+	if sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
+
+// OnSuccess decays host's backoff toward MinSleep (multiplicative
+// decrease).
+func (p *Pacer) OnSuccess(host string) {
+	if p == nil {
+		return
+	}
+
+	st := p.stateFor(host)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.sleep = time.Duration(float64(st.sleep) / p.decayConstant)
+	if st.sleep < p.minSleep {
+		st.sleep = p.minSleep
+	}
+}
+
+// OnThrottled doubles host's backoff, capped at MaxSleep
+// (multiplicative increase).
+func (p *Pacer) OnThrottled(host string) {
+	if p == nil {
+		return
+	}
+
+	st := p.stateFor(host)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.sleep *= 2
+	if st.sleep > p.maxSleep {
+		st.sleep = p.maxSleep
+	}
+	if st.sleep < p.minSleep {
+		st.sleep = p.minSleep
+	}
+}
+
+// Retries is how many times a throttled request should be retried
+// before a caller gives up. A nil receiver disables retries (0).
+func (p *Pacer) Retries() int {
+	if p == nil {
+		return 0
+	}
+	return p.retries
+}
+
+// IsThrottled reports whether status or body indicate a 429/503 or an
+// S3 SlowDown/ServiceUnavailable XML error code - the signals this
+// pacer backs off on. body may be nil when only a status is available
+// (e.g. a HEAD request).
+func IsThrottled(status int, body []byte) bool {
+	if status == 429 || status == 503 {
+		return true
+	}
+	if len(body) == 0 {
+		return false
+	}
+	s := string(body)
+	return strings.Contains(s, "<Code>SlowDown</Code>") || strings.Contains(s, "<Code>ServiceUnavailable</Code>")
+}
@@ -0,0 +1,107 @@
+package net
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// RetryStrategy models goamz's aws.AttemptStrategy: a request is
+// retried until it has been tried MinTries times or TotalTimeout has
+// elapsed, whichever permits more attempts, waiting an exponentially
+// increasing delay - doubling from InitialDelay up to MaxDelay - plus
+// up to 50% jitter between attempts so a burst of candidates retrying
+// in lockstep doesn't re-hammer the same host at once.
+type RetryStrategy struct {
+	MinTries     int
+	TotalTimeout time.Duration
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultRetryStrategy suits scanning S3/CDN endpoints through
+// NAT/CGNAT or behind a provider that throttles: at least 5 tries, and
+// further tries until 30s have passed, starting at 200ms and doubling
+// up to 5s.
+var DefaultRetryStrategy = RetryStrategy{
+	MinTries:     5,
+	TotalTimeout: 30 * time.Second,
+	InitialDelay: 200 * time.Millisecond,
+	MaxDelay:     5 * time.Second,
+}
+
+// retryState tracks one in-flight retry sequence against a
+// RetryStrategy's policy.
+type retryState struct {
+	strategy RetryStrategy
+	deadline time.Time
+	delay    time.Duration
+	tries    int
+}
+
+func (s RetryStrategy) start() *retryState {
+	return &retryState{
+		strategy: s,
+		deadline: time.Now().Add(s.TotalTimeout),
+		delay:    s.InitialDelay,
+	}
+}
+
+// shouldRetry records that an attempt just failed and reports whether
+// another should be made, sleeping for this attempt's backoff first -
+// retryAfter (from a response's Retry-After header) if positive,
+// otherwise the strategy's own exponential delay plus jitter.
+func (r *retryState) shouldRetry(retryAfter time.Duration) bool {
+	r.tries++
+	if r.tries >= r.strategy.MinTries && !time.Now().Before(r.deadline) {
+		return false
+	}
+
+	sleep := retryAfter
+	if sleep <= 0 {
+		sleep = r.delay + time.Duration(rand.Int63n(int64(r.delay)/2+1))
+	}
+	time.Sleep(sleep)
+
+	r.delay *= 2
+	if r.delay > r.strategy.MaxDelay {
+		r.delay = r.strategy.MaxDelay
+	}
+	return true
+}
+
+// isRetryableConnError reports whether err is a transient
+// connection-level failure worth retrying under a RetryStrategy -
+// connection reset, TLS handshake timeout, or fasthttp's own dial
+// timeout - as opposed to something retrying won't fix.
+func isRetryableConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, fasthttp.ErrDialTimeout) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "i/o timeout") ||
+		strings.Contains(msg, "tls:") ||
+		strings.Contains(msg, "EOF")
+}
+
+// parseRetryAfter parses an HTTP Retry-After header's delay-seconds
+// form, the only form cloud APIs send in practice. An HTTP-date value
+// or an empty header yield ok=false.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
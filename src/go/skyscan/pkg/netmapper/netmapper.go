@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"strings"
 	"sync"
 )
 
@@ -84,6 +83,25 @@ func (m *NetMapper) Lookup(ipStr string) *CloudInfo {
 	return nil
 }
 
+// LookupAll returns every overlapping CIDR across all providers for an
+// IP address, so callers can report nested ranges (e.g. an EC2 block
+// that also falls inside a narrower EC2_INSTANCE_CONNECT block).
+func (m *NetMapper) LookupAll(ipStr string) []*CloudInfo {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matches []*CloudInfo
+	matches = append(matches, m.aws.LookupAll(ip)...)
+	matches = append(matches, m.azure.LookupAll(ip)...)
+	matches = append(matches, m.gcp.LookupAll(ip)...)
+	return matches
+}
+
 // BatchLookup performs concurrent lookups for multiple IPs
 func (m *NetMapper) BatchLookup(ips []string) map[string]*CloudInfo {
 	results := make(map[string]*CloudInfo, len(ips))
@@ -257,9 +275,15 @@ func (m *NetMapper) loadGCP(path string) error {
 	return nil
 }
 
-// IPTrie implements a radix trie for efficient CIDR matching
+// IPTrie implements a pair of radix tries (one per address family) for
+// efficient CIDR matching. v4 and v6 prefixes are kept in separate
+// trees rooted at root4/root6 so that a v4 bit pattern can never be
+// walked against a v6-shaped insert (or vice versa) -- sharing one root
+// keyed by a heuristically-detected family was the source of incorrect
+// matches for IPv4-mapped IPv6 addresses.
 type IPTrie struct {
-	root  *TrieNode
+	root4 *TrieNode
+	root6 *TrieNode
 	count int
 }
 
@@ -270,7 +294,8 @@ type TrieNode struct {
 
 func NewIPTrie() *IPTrie {
 	return &IPTrie{
-		root: &TrieNode{},
+		root4: &TrieNode{},
+		root6: &TrieNode{},
 	}
 }
 
@@ -278,17 +303,24 @@ func (t *IPTrie) Count() int {
 	return t.count
 }
 
-// Insert adds a CIDR range to the trie
+// Insert adds a CIDR range to the trie, routed to the v4 or v6 root
+// based on the address family of the parsed network (not the presence
+// of a '.' in its string form, which IPv4-mapped IPv6 literals defeat).
 func (t *IPTrie) Insert(cidr string, info *CloudInfo) {
 	_, ipnet, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return
 	}
 
-	bits := ipToBits(ipnet.IP)
-	ones, _ := ipnet.Mask.Size()
+	root := t.root6
+	bits := ipnet.IP.To16()
+	ones, bitlen := ipnet.Mask.Size()
+	if ip4 := ipnet.IP.To4(); ip4 != nil && bitlen == 32 {
+		root = t.root4
+		bits = ip4
+	}
 
-	node := t.root
+	node := root
 	for i := 0; i < ones; i++ {
 		bit := (bits[i/8] >> (7 - i%8)) & 1
 		if node.children[bit] == nil {
@@ -300,56 +332,55 @@ func (t *IPTrie) Insert(cidr string, info *CloudInfo) {
 	t.count++
 }
 
-// Lookup finds the longest prefix match for an IP
+// Lookup finds the longest prefix match for an IP, walking to the
+// deepest reachable node while remembering the last node with info set
+// so a shorter, enclosing CIDR is still returned when a more specific
+// branch doesn't lead to an exact leaf.
 func (t *IPTrie) Lookup(ip net.IP) *CloudInfo {
-	// Normalize to 16 bytes
-	ip = ip.To16()
-	if ip == nil {
+	matches := t.LookupAll(ip)
+	if len(matches) == 0 {
 		return nil
 	}
+	return matches[len(matches)-1]
+}
 
-	// Handle IPv4 (use last 4 bytes)
-	isIPv4 := strings.Contains(ip.String(), ".")
+// LookupAll returns every CloudInfo whose CIDR contains ip, ordered from
+// least to most specific, so nested ranges (e.g. an EC2 block containing
+// a narrower EC2_INSTANCE_CONNECT block) are all reported.
+func (t *IPTrie) LookupAll(ip net.IP) []*CloudInfo {
 	var bits []byte
-	if isIPv4 {
-		ip4 := ip.To4()
-		if ip4 != nil {
-			bits = ip4
-		} else {
-			bits = ip[12:16]
-		}
+	var root *TrieNode
+	if ip4 := ip.To4(); ip4 != nil {
+		root = t.root4
+		bits = ip4
 	} else {
-		bits = ip
+		ip6 := ip.To16()
+		if ip6 == nil {
+			return nil
+		}
+		root = t.root6
+		bits = ip6
 	}
 
-	var lastMatch *CloudInfo
-	node := t.root
+	var matches []*CloudInfo
+	node := root
 
 	maxBits := len(bits) * 8
 	for i := 0; i < maxBits; i++ {
 		if node.info != nil {
-			lastMatch = node.info
+			matches = append(matches, node.info)
 		}
 
 		bit := (bits[i/8] >> (7 - i%8)) & 1
 		if node.children[bit] == nil {
-			break
+			return matches
 		}
 		node = node.children[bit]
 	}
 
-	// Check final node
 	if node.info != nil {
-		lastMatch = node.info
+		matches = append(matches, node.info)
 	}
 
-	return lastMatch
-}
-
-func ipToBits(ip net.IP) []byte {
-	// Use IPv4 if possible
-	if ip4 := ip.To4(); ip4 != nil {
-		return ip4
-	}
-	return ip.To16()
+	return matches
 }
@@ -0,0 +1,58 @@
+package osint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CrtShSeeder queries crt.sh's JSON endpoint for certificates issued to
+// domain or any of its subdomains.
+type CrtShSeeder struct{}
+
+func NewCrtShSeeder() *CrtShSeeder {
+	return &CrtShSeeder{}
+}
+
+func (s *CrtShSeeder) Name() string {
+	return "CRTSH"
+}
+
+type crtShEntry struct {
+	NameValue string `json:"name_value"`
+}
+
+func (s *CrtShSeeder) Seed(ctx context.Context, domain string) ([]string, error) {
+	url := fmt.Sprintf("https://crt.sh/?q=%%.%s&output=json", domain)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entries []crtShEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, entry := range entries {
+		// A single certificate's name_value can list several SANs, one per line.
+		for _, name := range strings.Split(entry.NameValue, "\n") {
+			if name = strings.TrimSpace(name); name != "" {
+				hosts = append(hosts, name)
+			}
+		}
+	}
+	return hosts, nil
+}
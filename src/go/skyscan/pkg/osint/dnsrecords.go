@@ -0,0 +1,50 @@
+package osint
+
+import (
+	"context"
+	"fmt"
+	"skyscan/pkg/net"
+)
+
+// commonSubdomainLabels is the probe list DNSRecordSeeder resolves
+// against domain. It's deliberately short - this seeder is a cheap,
+// always-available complement to CrtShSeeder/PassiveDNSSeeder, not a
+// brute-force subdomain scanner in its own right.
+var commonSubdomainLabels = []string{
+	"www", "api", "admin", "dev", "staging", "test", "app",
+	"mail", "vpn", "cdn", "static", "assets", "media",
+	"backup", "data", "internal", "portal", "auth",
+}
+
+// DNSRecordSeeder resolves a short list of common subdomain labels
+// against domain using the same resolver bucket enumeration uses, so
+// already-live infrastructure feeds back into candidate generation even
+// when no CT log or passive-DNS record exists for it yet.
+type DNSRecordSeeder struct {
+	resolver *net.DNSResolver
+}
+
+func NewDNSRecordSeeder(resolver *net.DNSResolver) *DNSRecordSeeder {
+	return &DNSRecordSeeder{resolver: resolver}
+}
+
+func (s *DNSRecordSeeder) Name() string {
+	return "DNS_RECORDS"
+}
+
+func (s *DNSRecordSeeder) Seed(ctx context.Context, domain string) ([]string, error) {
+	candidates := make([]string, len(commonSubdomainLabels))
+	for i, label := range commonSubdomainLabels {
+		candidates[i] = fmt.Sprintf("%s.%s", label, domain)
+	}
+
+	results := s.resolver.BatchCheck(ctx, candidates, 10)
+
+	var hosts []string
+	for _, result := range results {
+		if result.Exists {
+			hosts = append(hosts, result.Domain)
+		}
+	}
+	return hosts, nil
+}
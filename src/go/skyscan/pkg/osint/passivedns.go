@@ -0,0 +1,59 @@
+package osint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PassiveDNSSeeder queries a BufferOver/Anubis-style passive-DNS API,
+// which answers from historically observed DNS responses rather than
+// resolving domain live - it can surface hosts that no longer resolve
+// but were once in scope.
+type PassiveDNSSeeder struct{}
+
+func NewPassiveDNSSeeder() *PassiveDNSSeeder {
+	return &PassiveDNSSeeder{}
+}
+
+func (s *PassiveDNSSeeder) Name() string {
+	return "PASSIVE_DNS"
+}
+
+// passiveDNSResponse matches dns.bufferover.run's shape: each FDNS_A
+// entry is a "ip,hostname" pair.
+type passiveDNSResponse struct {
+	FDNSA []string `json:"FDNS_A"`
+}
+
+func (s *PassiveDNSSeeder) Seed(ctx context.Context, domain string) ([]string, error) {
+	url := fmt.Sprintf("https://dns.bufferover.run/dns?q=.%s", domain)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed passiveDNSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, record := range parsed.FDNSA {
+		if _, host, ok := strings.Cut(record, ","); ok {
+			hosts = append(hosts, strings.TrimSpace(host))
+		}
+	}
+	return hosts, nil
+}
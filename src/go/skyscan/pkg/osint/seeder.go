@@ -0,0 +1,82 @@
+// Package osint seeds permute.PermuteCore with real-world subdomain
+// labels gathered passively for a target domain, rather than relying on
+// pattern generation alone. It is distinct from pkg/providers/osint
+// (whose Source interface emits ready-to-check cloud bucket URLs):
+// a Seeder here emits subdomain labels of a domain, for
+// FullCloudRecon.ScanAll to fold back into mutation/keyword candidates.
+package osint
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Seeder is a passive-recon feed that enumerates subdomains of domain
+// without issuing any bucket/storage checks itself.
+type Seeder interface {
+	Name() string
+	// Seed returns the hostnames it can find for domain (e.g.
+	// "www.acme.com"). A failed lookup returns an error and an empty one
+	// returns (nil, nil); SeedAll drops both, so seeding is best-effort
+	// and never fails a scan.
+	Seed(ctx context.Context, domain string) ([]string, error)
+}
+
+// SeedAll runs every seeder concurrently against domain and returns the
+// deduplicated subdomain labels (the part of each hostname before
+// domain) it collected, e.g. "www" for "www.acme.com" against
+// domain "acme.com". Hostnames that don't end in domain, and the bare
+// domain itself, are dropped since they carry no label information.
+func SeedAll(ctx context.Context, domain string, seeders ...Seeder) []string {
+	labels := make(chan string, 256)
+	var wg sync.WaitGroup
+
+	for _, s := range seeders {
+		wg.Add(1)
+		go func(s Seeder) {
+			defer wg.Done()
+			hosts, err := s.Seed(ctx, domain)
+			if err != nil {
+				return
+			}
+			for _, host := range hosts {
+				if label, ok := labelOf(host, domain); ok {
+					select {
+					case <-ctx.Done():
+						return
+					case labels <- label:
+					}
+				}
+			}
+		}(s)
+	}
+
+	go func() {
+		wg.Wait()
+		close(labels)
+	}()
+
+	seen := make(map[string]bool)
+	var out []string
+	for label := range labels {
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		out = append(out, label)
+	}
+	return out
+}
+
+// labelOf extracts the subdomain label from host relative to domain,
+// e.g. labelOf("api.eu.acme.com", "acme.com") -> ("api.eu", true).
+func labelOf(host, domain string) (string, bool) {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	domain = strings.ToLower(domain)
+
+	if host == domain || !strings.HasSuffix(host, "."+domain) {
+		return "", false
+	}
+	return strings.TrimSuffix(host, "."+domain), true
+}
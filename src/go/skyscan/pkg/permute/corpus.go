@@ -0,0 +1,28 @@
+package permute
+
+// defaultCorpus seeds PermuteCore's Markov model with realistic
+// bucket/storage-account names, so it generates plausible candidates
+// even before LearnFromDiscovery has seen anything for the target. A
+// user can supplement this with their own examples via Config.Corpus
+// (see -corpus), e.g. names already confirmed inside the target's own
+// cloud footprint.
+var defaultCorpus = []string{
+	"acme-prod-data", "acme-prod-backups", "acme-dev-logs", "acme-staging-assets",
+	"company-data-lake", "company-prod-eu", "company-backups-2023",
+	"app-static-assets", "app-media-uploads", "app-user-content",
+	"web-cdn-assets", "web-prod-static", "web-logs-archive",
+	"data-lake-raw", "data-lake-curated", "data-warehouse-prod",
+	"analytics-events-prod", "analytics-raw-data", "analytics-export",
+	"infra-terraform-state", "infra-config-prod", "infra-backups-daily",
+	"logs-prod-us-east-1", "logs-archive-2022", "logs-audit-trail",
+	"backup-db-prod", "backup-daily-snapshot", "backup-weekly-full",
+	"media-assets-prod", "media-uploads-staging", "media-thumbnails",
+	"static-site-prod", "static-assets-cdn", "static-public-files",
+	"internal-tools-data", "internal-dashboard-assets", "internal-reports",
+	"dev-sandbox-data", "dev-test-fixtures", "dev-scratch",
+	"prod-us-west-2-data", "prod-eu-central-1-backups", "prod-ap-southeast-1-logs",
+	"customer-uploads-prod", "customer-exports-daily", "customer-data-archive",
+	"ml-models-prod", "ml-training-data", "ml-feature-store",
+	"finance-reports-prod", "finance-exports-monthly", "hr-documents-private",
+	"marketing-assets-public", "marketing-campaigns-2023", "sales-leads-export",
+}
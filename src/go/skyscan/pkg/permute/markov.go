@@ -0,0 +1,126 @@
+package permute
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// markov.go adds a statistical generator alongside GenerateAdvanced's
+// purely combinatorial rules: an order-N character Markov model trained
+// on real bucket/storage-account names, so candidates like
+// "acme-datalake-eu" fall out of the keyword itself instead of a fixed
+// affix list.
+
+// markovOrder is the number of trailing characters used as context for
+// each transition. 3-4 is the usual sweet spot for short identifiers
+// like bucket names - long enough to capture "-prod-", "-data", short
+// enough that the model doesn't just memorize the training corpus.
+const markovOrder = 3
+
+// markovSentinel brackets every trained name - markovOrder copies of it
+// pad the start and one trails the end - so the model also learns what a
+// name plausibly starts and stops with, not just its interior
+// transitions. Rune 0 can't appear in a real bucket name, so it doubles
+// safely as both the "nothing generated yet" and "stop here" signal.
+const markovSentinel = rune(0)
+
+// markovMaxLength is the bucket-name length cap (S3/GCS/Azure all cap at
+// or below 63) any generated candidate is truncated to before being
+// emitted.
+const markovMaxLength = 63
+
+// labelPattern mirrors pkg/providers/name_rules.go's genericNameRule: a
+// standard DNS label, lowercase alphanumeric with internal hyphens, no
+// leading/trailing hyphen. Duplicated rather than imported so pkg/permute
+// doesn't have to depend on pkg/providers for one regex.
+var labelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// isValidLabel reports whether candidate is a structurally possible DNS
+// label / bucket name.
+func isValidLabel(candidate string) bool {
+	return len(candidate) >= 2 && len(candidate) <= markovMaxLength && labelPattern.MatchString(candidate)
+}
+
+// markovModel is an order-markovOrder character transition table:
+// prefix (the last markovOrder runes seen) -> next rune -> occurrence
+// count.
+type markovModel struct {
+	transitions map[string]map[rune]int
+}
+
+func newMarkovModel() *markovModel {
+	return &markovModel{transitions: make(map[string]map[rune]int)}
+}
+
+// train folds name's character transitions into m, padded with
+// markovOrder leading start sentinels and one trailing end sentinel so
+// the model also learns plausible starts and stops.
+func (m *markovModel) train(name string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return
+	}
+
+	runes := make([]rune, 0, len(name)+markovOrder+1)
+	for i := 0; i < markovOrder; i++ {
+		runes = append(runes, markovSentinel)
+	}
+	runes = append(runes, []rune(name)...)
+	runes = append(runes, markovSentinel)
+
+	for i := markovOrder; i < len(runes); i++ {
+		prefix := string(runes[i-markovOrder : i])
+		next := m.transitions[prefix]
+		if next == nil {
+			next = make(map[rune]int)
+			m.transitions[prefix] = next
+		}
+		next[runes[i]]++
+	}
+}
+
+// sample draws one weighted-random next rune for prefix, reporting false
+// once prefix has never been observed (or the model picked the end
+// sentinel), so the caller knows to stop extending.
+func (m *markovModel) sample(prefix string) (rune, bool) {
+	next := m.transitions[prefix]
+	if len(next) == 0 {
+		return 0, false
+	}
+
+	total := 0
+	for _, count := range next {
+		total += count
+	}
+
+	pick := rand.Intn(total)
+	for r, count := range next {
+		if pick < count {
+			if r == markovSentinel {
+				return 0, false
+			}
+			return r, true
+		}
+		pick -= count
+	}
+	return 0, false
+}
+
+// generate extends seed one rune at a time, each draw conditioned on the
+// last markovOrder runes generated so far (seed included), stopping at
+// maxLen or once sample reports no further transitions.
+func (m *markovModel) generate(seed string, maxLen int) string {
+	runes := []rune(strings.Repeat(string(markovSentinel), markovOrder) + strings.ToLower(seed))
+
+	for len(runes)-markovOrder < maxLen {
+		prefix := string(runes[len(runes)-markovOrder:])
+		next, ok := m.sample(prefix)
+		if !ok {
+			break
+		}
+		runes = append(runes, next)
+	}
+
+	return string(runes[markovOrder:])
+}
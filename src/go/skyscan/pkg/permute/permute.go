@@ -3,6 +3,7 @@ package permute
 import (
 	"context"
 	"regexp"
+	"skyscan/pkg/core"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,6 +20,12 @@ type PermuteCore struct {
 	yearHints     []string       // Track discovered years
 	mu            sync.RWMutex
 	outputChan    chan string
+
+	// markov is an order-markovOrder character model trained on
+	// defaultCorpus plus Config.Corpus, and re-trained on every name
+	// LearnFromDiscovery sees - so GenerateMarkov/GenerateMarkovMix get
+	// sharper as a scan finds real hits (see markov.go).
+	markov *markovModel
 }
 
 // Patterns we look for in discovered names
@@ -29,14 +36,27 @@ var (
 	regionPattern = regexp.MustCompile(`(?i)(us-east|us-west|eu-west|eu-central|ap-south|ap-northeast)[-_]?\d?`)
 )
 
-// NewPermuteCore creates a new intelligent mutation engine
-func NewPermuteCore() *PermuteCore {
+// NewPermuteCore creates a new intelligent mutation engine, training its
+// Markov model on defaultCorpus plus config.Corpus (config may be nil,
+// equivalent to an empty Config).
+func NewPermuteCore(config *core.Config) *PermuteCore {
+	markov := newMarkovModel()
+	for _, name := range defaultCorpus {
+		markov.train(name)
+	}
+	if config != nil {
+		for _, name := range config.Corpus {
+			markov.train(name)
+		}
+	}
+
 	return &PermuteCore{
 		baseMutations: getDefaultMutations(),
 		regionHints:   make(map[string]int),
 		patternHints:  make(map[string]int),
 		yearHints:     []string{},
 		outputChan:    make(chan string, 10000),
+		markov:        markov,
 	}
 }
 
@@ -50,6 +70,11 @@ func (p *PermuteCore) LearnFromDiscovery(name string) []string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	// Feed the hit straight back into the Markov model, so the next
+	// GenerateMarkov/GenerateMarkovMix call samples from real discoveries
+	// too, not just defaultCorpus/Config.Corpus.
+	p.markov.train(name)
+
 	var newCandidates []string
 
 	// Extract and learn from years
@@ -257,9 +282,83 @@ func (p *PermuteCore) GenerateAdvanced(keyword string) []string {
 		)
 	}
 
+	// 8. Markov-model continuations (see markov.go) - statistical rather
+	// than combinatorial, so these look like real-world names instead of
+	// every fixed affix applied mechanically.
+	results = append(results, p.GenerateMarkov(keyword, markovSamplesPerKeyword)...)
+	results = append(results, p.GenerateMarkovMix(keyword)...)
+
+	return uniqueStrings(results)
+}
+
+// markovSamplesPerKeyword is how many continuations GenerateMarkov draws
+// per call from GenerateAdvanced.
+const markovSamplesPerKeyword = 8
+
+// GenerateMarkov samples n plausible continuations of keyword from p's
+// Markov model (see markov.go), keeping only the ones that are
+// structurally valid DNS labels/bucket names.
+func (p *PermuteCore) GenerateMarkov(keyword string, n int) []string {
+	var results []string
+	for i := 0; i < n; i++ {
+		if candidate := p.markov.generate(keyword, markovMaxLength); isValidLabel(candidate) {
+			results = append(results, candidate)
+		}
+	}
+	return uniqueStrings(results)
+}
+
+// GenerateMarkovMix interleaves substrings of keyword with the
+// highest-probability region/year/environment suffixes LearnFromDiscovery
+// has learned so far, then lets the Markov model extend each combination -
+// so as a scan finds real hits, the mix gets sharper instead of relying
+// only on defaultCorpus/Config.Corpus. Returns nil until at least one
+// suffix has been learned.
+func (p *PermuteCore) GenerateMarkovMix(keyword string) []string {
+	p.mu.RLock()
+	var suffixes []string
+	suffixes = append(suffixes, p.getTopHints(p.regionHints, 3)...)
+	for _, pattern := range p.getTopHints(p.patternHints, 5) {
+		// patternHints also stores component templates like "{0}-{1}",
+		// which aren't usable as a literal suffix - skip those.
+		if !strings.Contains(pattern, "{") {
+			suffixes = append(suffixes, pattern)
+		}
+	}
+	years := uniqueStrings(p.yearHints)
+	if len(years) > 3 {
+		years = years[len(years)-3:]
+	}
+	suffixes = append(suffixes, years...)
+	p.mu.RUnlock()
+
+	if len(suffixes) == 0 {
+		return nil
+	}
+
+	var results []string
+	for _, sub := range keywordSubstrings(keyword) {
+		for _, suffix := range suffixes {
+			if candidate := p.markov.generate(sub+"-"+suffix, markovMaxLength); isValidLabel(candidate) {
+				results = append(results, candidate)
+			}
+		}
+	}
 	return uniqueStrings(results)
 }
 
+// keywordSubstrings returns keyword itself plus every prefix of at least
+// 3 characters, the substrings GenerateMarkovMix seeds each learned
+// suffix with.
+func keywordSubstrings(keyword string) []string {
+	keyword = strings.ToLower(keyword)
+	substrings := []string{keyword}
+	for i := 3; i < len(keyword); i++ {
+		substrings = append(substrings, keyword[:i])
+	}
+	return substrings
+}
+
 // getTopHints returns the most frequently seen hints
 func (p *PermuteCore) getTopHints(hints map[string]int, limit int) []string {
 	type kv struct {
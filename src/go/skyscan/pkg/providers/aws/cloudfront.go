@@ -2,14 +2,29 @@ package aws
 
 import (
 	"context"
-	"fmt"
+	"net/url"
 	"skyscan/pkg/core"
+	"skyscan/pkg/dns"
+	"skyscan/pkg/fingerprint"
 	"skyscan/pkg/net"
+	"time"
 )
 
+// maxFingerprintBody is how much of a response body Check reads for
+// classification - a dangling-backend or WAF-challenge fingerprint
+// always appears near the top of the page.
+const maxFingerprintBody = 8192
+
+// CloudFrontProvider hunts for dangling CNAMEs rather than enumerating
+// CloudFront directly: distribution IDs are random, so there's nothing
+// to guess from a keyword alone. Instead it resolves the keyword's
+// conventional subdomains (see pkg/dns) and, when one's CNAME chain
+// terminates at CloudFront, S3, Azure CDN, or GCS, probes it for the
+// dangling-backend fingerprint that marks a subdomain takeover.
 type CloudFrontProvider struct {
-	client *net.Client
-	config *core.Config
+	client   *net.Client
+	config   *core.Config
+	resolver *dns.Resolver
 }
 
 func NewCloudFrontProvider(client *net.Client) *CloudFrontProvider {
@@ -24,62 +39,66 @@ func (p *CloudFrontProvider) Name() string {
 
 func (p *CloudFrontProvider) Init(config *core.Config) error {
 	p.config = config
+
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	p.resolver = dns.NewResolver(timeout)
+
 	return nil
 }
 
+// Generate resolves keyword's candidate subdomains and emits only the
+// ones whose CNAME chain lands on a known CDN/storage backend - there's
+// no point HTTP-checking a subdomain that doesn't point anywhere
+// interesting.
 func (p *CloudFrontProvider) Generate(ctx context.Context, keyword string, output chan<- string) {
-	// Pattern: https://<id>.cloudfront.net
-	// IDs are random, so we can't guess them easily.
-	// BUT, sometimes people use CNAMEs.
-	// We can check if `keyword.cloudfront.net` exists (unlikely but possible if they got a vanity one? No, CF doesn't do vanity IDs).
-	// However, we can check for S3 buckets that might be backed by CF? No.
-
-	// Actually, this provider might be better suited for checking CNAMEs if we had a domain list.
-	// Since we are keyword based, we can try to guess if there are any mapped CNAMEs? No.
-
-	// Let's try checking if the keyword is used as a CNAME target? No.
-
-	// Wait, some tools check `keyword.cloudfront.net`? No, that's not how it works.
-	// But maybe we can check `assets.keyword.com` if we had the domain.
-
-	// Let's implement a "Best Effort" check for common misconfigurations or related assets.
-	// Actually, let's skip CloudFront for keyword scanning as it's ineffective without a domain list to check CNAMEs against.
-	// But the user asked for "every script".
-
-	// Let's check `keyword.s3-website-us-east-1.amazonaws.com` instead? That's S3.
-
-	// Let's implement S3 Website endpoints as part of this file (or S3).
-	// Let's rename this to "AWS_EXTRA" and check S3 Websites.
-
-	// S3 Website: http://<bucket>.s3-website-<region>.amazonaws.com
-
-	regions := []string{"us-east-1", "us-west-1", "us-west-2", "eu-west-1"}
-
-	for _, region := range regions {
-		target := fmt.Sprintf("http://%s.s3-website-%s.amazonaws.com", keyword, region)
+	for _, finding := range p.resolver.Discover(ctx, keyword) {
 		select {
 		case <-ctx.Done():
 			return
-		case output <- target:
+		case output <- "http://" + finding.Host:
 		}
 	}
 }
 
+// Check re-resolves target's CNAME (it may have changed since Generate)
+// and, if it still matches a KnownEdgeTarget, classifies the response
+// via pkg/fingerprint. Only TakeoverCandidate is reported - a target
+// whose CNAME no longer matches, or whose body classifies as healthy,
+// not-found, or a WAF/parked-domain page (never the real backend, so
+// never trustworthy either way), is a no-op result rather than an
+// error: most CNAMEd hosts are perfectly healthy.
 func (p *CloudFrontProvider) Check(ctx context.Context, target string) (*core.Result, error) {
-	status, size, err := p.client.Check(target)
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	cname, err := p.resolver.ResolveCNAME(ctx, u.Hostname())
+	if err != nil || cname == "" {
+		return nil, nil
+	}
+
+	edgeTarget, ok := dns.MatchEdgeTarget(cname)
+	if !ok {
+		return nil, nil
+	}
+
+	status, _, body, err := p.client.CheckWithBody(target, maxFingerprintBody)
 	if err != nil {
 		return nil, err
 	}
 
-	if status == 0 || status == 404 {
+	if fingerprint.Classify(status, body) != fingerprint.TakeoverCandidate {
 		return nil, nil
 	}
 
 	return &core.Result{
 		URL:         target,
-		Provider:    "AWS_S3_WEBSITE",
+		Provider:    edgeTarget.Name,
 		Status:      status,
-		Size:        size,
-		Permissions: "PUBLIC",
+		Permissions: string(fingerprint.TakeoverCandidate),
 	}, nil
 }
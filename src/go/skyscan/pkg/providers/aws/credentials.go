@@ -0,0 +1,178 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Credentials holds a resolved set of AWS credentials for SigV4
+// signing (see sigv4.go).
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// ResolveCredentials finds AWS credentials the way the AWS CLI does,
+// cheaply: an explicit profile's section in ~/.aws/credentials, then
+// AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN, then the
+// role attached to the EC2 instance via IMDSv2. Returns (nil, nil) -
+// not an error - when nothing is configured, so the caller can treat
+// "no creds" as "fall back to anonymous".
+func ResolveCredentials(profile string) (*Credentials, error) {
+	if profile != "" {
+		if creds, err := credentialsFromProfile(profile); err == nil {
+			return creds, nil
+		}
+	}
+	if creds := credentialsFromEnv(); creds != nil {
+		return creds, nil
+	}
+	creds, err := credentialsFromIMDS()
+	if err != nil {
+		// No reachable instance role: nothing is configured.
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// credentialsFromEnv reads the same three environment variables the
+// AWS CLI and SDKs honor.
+func credentialsFromEnv() *Credentials {
+	id := os.Getenv("AWS_ACCESS_KEY_ID")
+	secret := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if id == "" || secret == "" {
+		return nil
+	}
+	return &Credentials{
+		AccessKeyID:     id,
+		SecretAccessKey: secret,
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// credentialsFromProfile reads the [profile] section of
+// ~/.aws/credentials, a minimal INI dialect (no nesting, no quoting).
+func credentialsFromProfile(profile string) (*Credentials, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(home, ".aws", "credentials")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &Credentials{}
+	inSection := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = strings.TrimSpace(strings.Trim(line, "[]")) == profile
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			creds.AccessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			creds.SecretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			creds.SessionToken = strings.TrimSpace(value)
+		}
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+	return creds, nil
+}
+
+// imdsClient is short-timeout since the metadata endpoint is either
+// answered in milliseconds (on an EC2 instance) or not reachable at
+// all (everywhere else).
+var imdsClient = &http.Client{Timeout: 2 * time.Second}
+
+// credentialsFromIMDS fetches the role attached to the current EC2
+// instance via IMDSv2 (token-gated, per AWS's recommended hardening
+// against SSRF against the older tokenless IMDSv1).
+func credentialsFromIMDS() (*Credentials, error) {
+	tokenReq, err := http.NewRequest(http.MethodPut, "http://169.254.169.254/latest/api/token", nil)
+	if err != nil {
+		return nil, err
+	}
+	tokenReq.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+
+	tokenResp, err := imdsClient.Do(tokenReq)
+	if err != nil {
+		return nil, err
+	}
+	defer tokenResp.Body.Close()
+	token, err := io.ReadAll(tokenResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	roleResp, err := imdsGet("http://169.254.169.254/latest/meta-data/iam/security-credentials/", string(token))
+	if err != nil {
+		return nil, err
+	}
+	role := strings.TrimSpace(string(roleResp))
+	if role == "" {
+		return nil, fmt.Errorf("no IAM role attached to this instance")
+	}
+
+	credResp, err := imdsGet("http://169.254.169.254/latest/meta-data/iam/security-credentials/"+role, string(token))
+	if err != nil {
+		return nil, err
+	}
+
+	var imds struct {
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string `json:"SecretAccessKey"`
+		Token           string `json:"Token"`
+	}
+	if err := json.Unmarshal(credResp, &imds); err != nil {
+		return nil, err
+	}
+
+	return &Credentials{
+		AccessKeyID:     imds.AccessKeyID,
+		SecretAccessKey: imds.SecretAccessKey,
+		SessionToken:    imds.Token,
+	}, nil
+}
+
+func imdsGet(url, token string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+
+	resp, err := imdsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
@@ -5,17 +5,20 @@ import (
 	"fmt"
 	"skyscan/pkg/core"
 	"skyscan/pkg/net"
+	"strings"
 )
 
 type ElasticBeanstalkProvider struct {
-	client  *net.Client
-	config  *core.Config
-	regions []string
+	client      *net.Client
+	dnsResolver *net.DNSResolver
+	config      *core.Config
+	regions     []string
 }
 
-func NewElasticBeanstalkProvider(client *net.Client) *ElasticBeanstalkProvider {
+func NewElasticBeanstalkProvider(client *net.Client, dnsResolver *net.DNSResolver) *ElasticBeanstalkProvider {
 	return &ElasticBeanstalkProvider{
-		client: client,
+		client:      client,
+		dnsResolver: dnsResolver,
 		regions: []string{
 			"us-east-1", "us-east-2", "us-west-1", "us-west-2",
 			"eu-west-1", "eu-central-1", "eu-west-2",
@@ -67,6 +70,15 @@ func (p *ElasticBeanstalkProvider) Generate(ctx context.Context, keyword string,
 }
 
 func (p *ElasticBeanstalkProvider) Check(ctx context.Context, target string) (*core.Result, error) {
+	// *.elasticbeanstalk.com is a textbook wildcard zone in some
+	// regions (every unclaimed label resolves to a default parking
+	// page) - filter those out before spending an HTTP request on them.
+	dnsHost := strings.TrimPrefix(strings.TrimPrefix(target, "http://"), "https://")
+	dnsResult := p.dnsResolver.CheckExists(ctx, dnsHost)
+	if dnsResult.Wildcard {
+		return nil, nil
+	}
+
 	status, size, err := p.client.Check(target)
 	if err != nil {
 		return nil, err
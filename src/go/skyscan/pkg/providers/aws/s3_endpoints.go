@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"strings"
+
+	"skyscan/pkg/providers"
+)
+
+// S3Endpoint describes one S3-compatible storage backend: rclone's s3
+// backend speaks the same protocol to dozens of providers under one
+// code path, and S3ProviderV2 follows the same shape instead of
+// hardcoding *.s3.amazonaws.com shapes.
+//
+// VirtualHostTemplate and PathStyleTemplate are host patterns with
+// "{bucket}" and "{region}" placeholders substituted per candidate;
+// either may be empty if the provider doesn't support that addressing
+// mode. RegionList drives how many regional candidates are generated
+// per bucket name; a nil/empty list means the provider has no region
+// in its hostname and a single candidate is generated.
+type S3Endpoint struct {
+	Name                 string
+	VirtualHostTemplate  string
+	PathStyleTemplate    string
+	RegionList           []string
+	RequiresPathStyle    bool
+	SupportsAcceleration bool
+}
+
+// s3Endpoints are the registered S3-compatible backends Generate/Check
+// iterate. Order matters only for scan output ordering.
+var s3Endpoints = []S3Endpoint{
+	{
+		Name:                 "AWS",
+		VirtualHostTemplate:  "{bucket}.s3.{region}.amazonaws.com",
+		PathStyleTemplate:    "s3.amazonaws.com/{bucket}",
+		RegionList:           s3Regions,
+		SupportsAcceleration: true,
+	},
+	{
+		Name:                "Wasabi",
+		VirtualHostTemplate: "{bucket}.s3.{region}.wasabisys.com",
+		RegionList: []string{
+			"us-east-1", "us-east-2", "us-west-1", "us-central-1",
+			"eu-central-1", "eu-west-1", "eu-west-2", "ap-northeast-1", "ap-southeast-2",
+		},
+	},
+	{
+		Name:                "Backblaze B2",
+		VirtualHostTemplate: "{bucket}.s3.{region}.backblazeb2.com",
+		RegionList: []string{
+			"us-west-000", "us-west-001", "us-west-002",
+			"eu-central-003", "us-east-005",
+		},
+	},
+	{
+		Name:                "DigitalOcean Spaces",
+		VirtualHostTemplate: "{bucket}.{region}.digitaloceanspaces.com",
+		RegionList:          []string{"nyc3", "sfo2", "sfo3", "ams3", "sgp1", "fra1", "syd1"},
+	},
+	{
+		Name:                "Linode Object Storage",
+		VirtualHostTemplate: "{bucket}.{region}.linodeobjects.com",
+		RegionList:          []string{"us-east-1", "us-southeast-1", "eu-central-1", "ap-south-1"},
+	},
+	{
+		Name:                "Cloudflare R2",
+		VirtualHostTemplate: "{bucket}.r2.cloudflarestorage.com",
+	},
+	{
+		Name:                "Alibaba OSS",
+		VirtualHostTemplate: "{bucket}.oss-{region}.aliyuncs.com",
+		RegionList: []string{
+			"cn-hangzhou", "cn-shanghai", "cn-beijing", "cn-shenzhen", "cn-hongkong",
+			"us-west-1", "us-east-1", "ap-southeast-1", "eu-central-1",
+		},
+	},
+	{
+		Name:                "Scaleway",
+		VirtualHostTemplate: "{bucket}.s3.{region}.scw.cloud",
+		RegionList:          []string{"fr-par", "nl-ams", "pl-waw"},
+	},
+}
+
+// sovereignS3Endpoints builds one S3Endpoint per AWS sovereign
+// partition named in partitionIDs (aws-cn, aws-us-gov, aws-secret,
+// aws-top-secret - see pkg/providers.EndpointModel), reading its
+// dnsSuffix and region list from model instead of a second hardcoded
+// table. The commercial "aws" partition is always enumerated via the
+// static s3Endpoints entry above and is skipped here if named.
+func sovereignS3Endpoints(model *providers.EndpointModel, partitionIDs []string) []S3Endpoint {
+	var eps []S3Endpoint
+	for _, id := range partitionIDs {
+		if id == "" || id == "aws" || !strings.HasPrefix(id, "aws") {
+			continue
+		}
+		partition, ok := model.Partition(id)
+		if !ok {
+			continue
+		}
+		eps = append(eps, S3Endpoint{
+			Name:                sovereignS3Name(id),
+			VirtualHostTemplate: "{bucket}.s3.{region}." + partition.DNSSuffix,
+			RegionList:          partition.RegionNames(),
+		})
+	}
+	return eps
+}
+
+// sovereignS3Name renders a partition id as the display name Check's
+// ep.Name == "AWS" comparisons and scan output expect.
+func sovereignS3Name(partitionID string) string {
+	switch partitionID {
+	case "aws-cn":
+		return "AWS China"
+	case "aws-us-gov":
+		return "AWS GovCloud"
+	case "aws-secret":
+		return "AWS Secret"
+	case "aws-top-secret":
+		return "AWS Top Secret"
+	default:
+		return partitionID
+	}
+}
+
+// customS3Endpoint builds the S3Endpoint for a CLI-provided generic
+// endpoint (Ceph, MinIO, or anything else speaking the S3 API that
+// isn't in s3Endpoints). Both addressing styles are registered - a
+// self-hosted deployment may or may not have wildcard DNS/TLS for
+// virtual-hosted buckets, and Config.BucketLookupType (or its Auto
+// fallback in S3ProviderV2.Check) is what decides which one actually
+// gets used.
+func customS3Endpoint(host string) S3Endpoint {
+	return S3Endpoint{
+		Name:                "S3-Compatible (" + host + ")",
+		VirtualHostTemplate: "{bucket}." + host,
+		PathStyleTemplate:   host + "/{bucket}",
+	}
+}
+
+// expand renders tmpl for bucket in every region of ep.RegionList, or
+// once region-less if tmpl doesn't place a region at all (a provider
+// can have one template that needs a region - VirtualHostTemplate -
+// and another that doesn't, e.g. AWS's global PathStyleTemplate).
+func (ep S3Endpoint) expand(tmpl, bucket string) []string {
+	if tmpl == "" {
+		return nil
+	}
+	if !strings.Contains(tmpl, "{region}") || len(ep.RegionList) == 0 {
+		return []string{substitute(tmpl, bucket, "")}
+	}
+
+	hosts := make([]string, 0, len(ep.RegionList))
+	for _, region := range ep.RegionList {
+		hosts = append(hosts, substitute(tmpl, bucket, region))
+	}
+	return hosts
+}
+
+func substitute(tmpl, bucket, region string) string {
+	r := strings.NewReplacer("{bucket}", bucket, "{region}", region)
+	return r.Replace(tmpl)
+}
+
+// matchBucket extracts the bucket name from host if it matches one of
+// ep's templates, mirroring the placeholder back out instead of
+// re-deriving it textually for every provider's own hostname quirks.
+func (ep S3Endpoint) matchBucket(host string) (bucket string, ok bool) {
+	for _, tmpl := range []string{ep.VirtualHostTemplate, ep.PathStyleTemplate} {
+		if tmpl == "" {
+			continue
+		}
+		if bucket, ok := matchTemplate(tmpl, host); ok {
+			return bucket, true
+		}
+	}
+	return "", false
+}
+
+// matchTemplate anchors host against tmpl's literal segments around
+// "{bucket}" and, if present, "{region}", and returns whatever matched
+// "{bucket}". Every literal segment must match exactly so two
+// providers whose templates merely share a substring (e.g. every AWS
+// and Wasabi host contains ".s3.") can't cross-match each other.
+func matchTemplate(tmpl, host string) (string, bool) {
+	const bucketPlaceholder = "{bucket}"
+	const regionPlaceholder = "{region}"
+
+	bIdx := strings.Index(tmpl, bucketPlaceholder)
+	if bIdx == -1 {
+		return "", false
+	}
+	before := tmpl[:bIdx]
+	after := tmpl[bIdx+len(bucketPlaceholder):]
+
+	if !strings.HasPrefix(host, before) {
+		return "", false
+	}
+	rest := host[len(before):]
+
+	rIdx := strings.Index(after, regionPlaceholder)
+	if rIdx == -1 {
+		// No region: the bucket is everything up to the literal tail,
+		// which must match exactly to the end of host.
+		if !strings.HasSuffix(rest, after) {
+			return "", false
+		}
+		end := len(rest) - len(after)
+		if end <= 0 {
+			return "", false
+		}
+		return rest[:end], true
+	}
+
+	// Region in the middle: split "after" into the literal head
+	// (between bucket and region) and tail (after region), and anchor
+	// both - the region text itself is a wildcard.
+	head := after[:rIdx]
+	tail := after[rIdx+len(regionPlaceholder):]
+
+	headIdx := strings.Index(rest, head)
+	if headIdx <= 0 {
+		return "", false
+	}
+	bucket := rest[:headIdx]
+	afterHead := rest[headIdx+len(head):]
+	if !strings.HasSuffix(afterHead, tail) || len(afterHead) <= len(tail) {
+		return "", false
+	}
+	return bucket, true
+}
@@ -2,13 +2,17 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"net/url"
 	"skyscan/pkg/core"
 	"skyscan/pkg/net"
+	"skyscan/pkg/providers"
 	"strings"
 	"sync"
+	"time"
 )
 
 // S3ProviderV2 implements enhanced S3 enumeration with:
@@ -22,6 +26,14 @@ type S3ProviderV2 struct {
 	config      *core.Config
 	mu          sync.RWMutex
 	regionCache map[string]string
+
+	credsOnce sync.Once
+	creds     *Credentials
+
+	// endpointModel supplies the dnsSuffix/region tables behind
+	// sovereignS3Endpoints (see s3_endpoints.go), loaded in Init from
+	// config.EndpointsPath.
+	endpointModel *providers.EndpointModel
 }
 
 // S3 regions for regional endpoint enumeration
@@ -51,78 +63,214 @@ func (p *S3ProviderV2) Name() string {
 
 func (p *S3ProviderV2) Init(config *core.Config) error {
 	p.config = config
+
+	model, err := providers.LoadEndpointModel(config.EndpointsPath)
+	if err != nil {
+		return fmt.Errorf("load endpoint model: %w", err)
+	}
+	p.endpointModel = model
 	return nil
 }
 
-// Generate creates candidate bucket URLs with intelligent mutations
+// Generate creates candidate bucket URLs across every registered
+// S3Endpoint (see s3_endpoints.go): AWS plus the S3-compatible
+// providers (Wasabi, Backblaze, DigitalOcean Spaces, ...), and a
+// CLI-provided generic endpoint if Config.S3CompatibleEndpoint is set.
+// Config.BucketLookupType gates which addressing style(s) are emitted
+// per candidate - "" and "auto" (the default) emit both, "virtual-host"
+// and "path" emit one only.
 func (p *S3ProviderV2) Generate(ctx context.Context, keyword string, output chan<- string) {
-	// Standard S3 endpoint formats
-	endpoints := []string{
-		"%s.s3.amazonaws.com",            // Virtual-hosted style
-		"s3.amazonaws.com/%s",            // Path style (legacy)
-		"%s.s3-accelerate.amazonaws.com", // Transfer Acceleration
+	names := []string{keyword}
+	for _, mut := range p.config.Mutations {
+		names = append(names,
+			keyword+mut, keyword+"-"+mut, keyword+"_"+mut, keyword+"."+mut,
+			mut+keyword, mut+"-"+keyword, mut+"_"+keyword, mut+"."+keyword,
+		)
 	}
 
-	// Base keyword
-	for _, ep := range endpoints {
+	lookup := p.bucketLookupType()
+
+	emit := func(host string) bool {
 		select {
 		case <-ctx.Done():
-			return
-		case output <- fmt.Sprintf("http://"+ep, keyword):
+			return false
+		case output <- "http://" + host:
+			return true
 		}
 	}
 
-	// Apply mutations from config
-	for _, mut := range p.config.Mutations {
-		for _, ep := range endpoints[:1] { // Only use virtual-hosted for mutations
-			// Suffix mutations
-			select {
-			case <-ctx.Done():
-				return
-			case output <- fmt.Sprintf("http://"+ep, keyword+mut):
-			case output <- fmt.Sprintf("http://"+ep, keyword+"-"+mut):
-			case output <- fmt.Sprintf("http://"+ep, keyword+"_"+mut):
-			case output <- fmt.Sprintf("http://"+ep, keyword+"."+mut):
+	for _, ep := range p.endpoints() {
+		for _, name := range names {
+			if !ep.RequiresPathStyle && lookup != "path" {
+				for _, host := range ep.expand(ep.VirtualHostTemplate, name) {
+					if !emit(host) {
+						return
+					}
+				}
 			}
-
-			// Prefix mutations
-			select {
-			case <-ctx.Done():
+			if lookup != "virtual-host" {
+				for _, host := range ep.expand(ep.PathStyleTemplate, name) {
+					if !emit(host) {
+						return
+					}
+				}
+			}
+			if ep.Name == "AWS" && lookup != "path" {
+				// The legacy global virtual-hosted form (no region in
+				// the hostname) still resolves for any region via
+				// Route 53, alongside the per-region hosts above.
+				if !emit(name + ".s3.amazonaws.com") {
+					return
+				}
+			}
+		}
+		if ep.SupportsAcceleration && lookup != "path" {
+			if !emit(substitute("{bucket}.s3-accelerate.amazonaws.com", keyword, "")) {
 				return
-			case output <- fmt.Sprintf("http://"+ep, mut+keyword):
-			case output <- fmt.Sprintf("http://"+ep, mut+"-"+keyword):
-			case output <- fmt.Sprintf("http://"+ep, mut+"_"+keyword):
-			case output <- fmt.Sprintf("http://"+ep, mut+"."+keyword):
 			}
 		}
 	}
+}
 
-	// Regional endpoints (for buckets in specific regions)
-	for _, region := range s3Regions {
-		select {
-		case <-ctx.Done():
-			return
-		case output <- fmt.Sprintf("http://%s.s3.%s.amazonaws.com", keyword, region):
+// bucketLookupType normalizes Config.BucketLookupType to "auto",
+// "virtual-host", or "path", defaulting to "auto" for an empty or
+// unrecognized value.
+func (p *S3ProviderV2) bucketLookupType() string {
+	if p.config == nil {
+		return "auto"
+	}
+	switch p.config.BucketLookupType {
+	case "virtual-host", "path":
+		return p.config.BucketLookupType
+	default:
+		return "auto"
+	}
+}
+
+// endpoints returns the registered S3Endpoints (AWS commercial plus the
+// S3-compatible providers) plus, if configured, a generic endpoint
+// (both addressing styles, see customS3Endpoint) for a self-hosted
+// Ceph/MinIO deployment and any sovereign
+// AWS partitions named in Config.Partitions (aws-cn, aws-us-gov,
+// aws-secret, aws-top-secret). Sovereign partitions are opt-in so
+// target counts don't explode by default.
+func (p *S3ProviderV2) endpoints() []S3Endpoint {
+	eps := s3Endpoints
+
+	if p.config != nil && p.config.S3CompatibleEndpoint != "" {
+		eps = append(append([]S3Endpoint{}, eps...), customS3Endpoint(p.config.S3CompatibleEndpoint))
+	}
+
+	if p.config != nil && len(p.config.Partitions) > 0 && p.endpointModel != nil {
+		eps = append(append([]S3Endpoint{}, eps...), sovereignS3Endpoints(p.endpointModel, p.config.Partitions)...)
+	}
+
+	return eps
+}
+
+// matchEndpoint finds which registered S3Endpoint produced target's
+// host and extracts the bucket name from it, also recognizing the two
+// AWS-only hosts Generate emits outside the endpoint templates: the
+// region-less legacy virtual host and the Transfer Acceleration host.
+func (p *S3ProviderV2) matchEndpoint(target string) (S3Endpoint, string) {
+	host := strings.TrimPrefix(strings.TrimPrefix(target, "http://"), "https://")
+
+	for _, ep := range p.endpoints() {
+		if bucket, ok := ep.matchBucket(host); ok {
+			return ep, bucket
+		}
+	}
+
+	for _, suffix := range []string{".s3.amazonaws.com", ".s3-accelerate.amazonaws.com"} {
+		if strings.HasSuffix(host, suffix) {
+			return s3Endpoints[0], strings.TrimSuffix(host, suffix)
 		}
 	}
+	return S3Endpoint{}, ""
+}
+
+// pathStyleFallback reports whether target's virtual-host probe result
+// looks like it hit an endpoint that doesn't support virtual-host
+// addressing - a TLS failure (SNI mismatch, common when a self-hosted
+// deployment's certificate doesn't cover "<bucket>.<host>"), or a
+// 400/301 whose body carries S3's AuthorizationHeaderMalformed or
+// PermanentRedirect error code - and, if so, returns the bucket's
+// path-style URL to retry. Only bucketLookupType()=="auto" ever falls
+// back; "virtual-host"/"path" are honored exactly as configured. A 301
+// on AWS itself is left alone (Check's own region-redirect handling
+// already covers that case); elsewhere it's as likely to mean "wrong
+// style" as "wrong region" given these backends don't speak AWS's
+// multi-region-redirect protocol.
+func (p *S3ProviderV2) pathStyleFallback(ep S3Endpoint, target, bucketName string, status int, err error) (string, bool) {
+	if p.bucketLookupType() != "auto" || ep.PathStyleTemplate == "" {
+		return "", false
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(target, "http://"), "https://")
+	if !strings.HasPrefix(host, bucketName+".") {
+		return "", false // already path-style, or some other shape
+	}
+
+	wrongStyle := isTLSAddressingError(err)
+	if !wrongStyle && status == 400 {
+		wrongStyle = p.bodyIndicatesWrongStyle(target)
+	}
+	if !wrongStyle && status == 301 && ep.Name != "AWS" {
+		wrongStyle = p.bodyIndicatesWrongStyle(target)
+	}
+	if !wrongStyle {
+		return "", false
+	}
+
+	hosts := ep.expand(ep.PathStyleTemplate, bucketName)
+	if len(hosts) == 0 {
+		return "", false
+	}
+	return "http://" + hosts[0], true
+}
+
+// bodyIndicatesWrongStyle fetches target's body and reports whether it
+// carries S3's AuthorizationHeaderMalformed or PermanentRedirect error
+// code - the two signals minio-go treats as "retry path-style" when
+// negotiating BucketLookupAuto.
+func (p *S3ProviderV2) bodyIndicatesWrongStyle(target string) bool {
+	body, err := p.httpClient.GetBody(target)
+	if err != nil {
+		return false
+	}
+	text := string(body)
+	return strings.Contains(text, "AuthorizationHeaderMalformed") || strings.Contains(text, "PermanentRedirect")
+}
+
+// isTLSAddressingError reports whether err looks like a TLS handshake
+// failure rather than an ordinary connection/timeout error - the shape
+// a virtual-host request takes against an endpoint whose certificate
+// doesn't cover the bucket subdomain.
+func isTLSAddressingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "x509") || strings.Contains(msg, "tls:")
 }
 
 // Check performs hybrid DNS + HTTP validation
 func (p *S3ProviderV2) Check(ctx context.Context, target string) (*core.Result, error) {
-	// Extract bucket name from URL
-	bucketName := extractBucketName(target)
+	// Identify which registered endpoint produced target and recover
+	// its bucket name
+	ep, bucketName := p.matchEndpoint(target)
 	if bucketName == "" {
 		return nil, fmt.Errorf("could not extract bucket name from %s", target)
 	}
 
 	// Phase 1: DNS Check (stealth)
-	dnsHost := fmt.Sprintf("%s.s3.amazonaws.com", bucketName)
+	dnsHost := strings.TrimPrefix(strings.TrimPrefix(target, "http://"), "https://")
 	dnsResult := p.dnsResolver.CheckExists(ctx, dnsHost)
 
 	if dnsResult.Error != nil {
 		return &core.Result{
 			URL:      target,
-			Provider: "AWS",
+			Provider: ep.Name,
 			Error:    dnsResult.Error.Error(),
 		}, nil
 	}
@@ -134,10 +282,16 @@ func (p *S3ProviderV2) Check(ctx context.Context, target string) (*core.Result,
 
 	// Phase 2: HTTP Check (detailed analysis)
 	status, size, err := p.httpClient.Check(target)
+
+	if fallback, ok := p.pathStyleFallback(ep, target, bucketName, status, err); ok {
+		target = fallback
+		status, size, err = p.httpClient.Check(target)
+	}
+
 	if err != nil {
 		return &core.Result{
 			URL:      target,
-			Provider: "AWS",
+			Provider: ep.Name,
 			Status:   0,
 			Error:    err.Error(),
 		}, nil
@@ -145,7 +299,7 @@ func (p *S3ProviderV2) Check(ctx context.Context, target string) (*core.Result,
 
 	result := &core.Result{
 		URL:      target,
-		Provider: "AWS",
+		Provider: ep.Name,
 		Status:   status,
 		Size:     size,
 	}
@@ -154,24 +308,32 @@ func (p *S3ProviderV2) Check(ctx context.Context, target string) (*core.Result,
 	switch status {
 	case 200:
 		result.Permissions = "PUBLIC_READ"
-		// Attempt to list files
-		if body, err := p.httpClient.GetBody(target); err == nil {
-			result.Files = parseS3XMLv2(body)
+		result.Files, result.Truncated = p.listObjects(target)
+		if ep.Name == "AWS" && p.config.HarvestBucketConfig {
+			p.harvestBucketConfig(ctx, bucketName, result)
 		}
 	case 204:
 		result.Permissions = "PUBLIC_EMPTY"
+		if ep.Name == "AWS" && p.config.HarvestBucketConfig {
+			p.harvestBucketConfig(ctx, bucketName, result)
+		}
 	case 403:
 		result.Permissions = "AUTHENTICATED"
 		// Could be accessible with valid AWS creds
+		if ep.Name == "AWS" && p.config.Authenticated {
+			p.checkAuthenticatedRead(target, result)
+			p.checkAuthenticatedWrite(target, result)
+		}
 	case 404:
 		// This shouldn't happen if DNS resolved, but bucket might be deleted
 		return nil, nil
 	case 301, 307:
 		result.Permissions = "REDIRECT"
 		// Bucket exists but wrong region - detect region
-		region := p.detectRegion(target)
-		if region != "" {
-			result.Error = fmt.Sprintf("redirect to region: %s", region)
+		if ep.Name == "AWS" {
+			if region := p.detectRegion(target); region != "" {
+				result.Error = fmt.Sprintf("redirect to region: %s", region)
+			}
 		}
 	default:
 		result.Permissions = "UNKNOWN"
@@ -180,15 +342,122 @@ func (p *S3ProviderV2) Check(ctx context.Context, target string) (*core.Result,
 	return result, nil
 }
 
-// CheckWithACL performs deep ACL analysis (requires valid bucket)
+// checkAuthenticatedRead promotes a 403 to a signed follow-up: buckets
+// that reject anonymous access are frequently readable by any
+// authenticated AWS principal (the AuthenticatedUsers ACL group). It
+// signs a GET /?list-type=2 and a GET /?acl with resolved AWS
+// credentials and, if either succeeds, relabels result as
+// AUTHENTICATED_READ_CONFIRMED. Failures here are informational only -
+// result already reflects the anonymous 403, so there's nothing to
+// surface as an error.
+func (p *S3ProviderV2) checkAuthenticatedRead(target string, result *core.Result) {
+	creds := p.awsCredentials()
+	if creds == nil {
+		return
+	}
+
+	region := p.detectRegion(target)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	for _, suffix := range []string{"/?list-type=2", "/?acl"} {
+		signedURL := target + suffix
+		headers, err := signRequest(http.MethodGet, signedURL, region, *creds)
+		if err != nil {
+			continue
+		}
+
+		_, status, err := p.httpClient.GetBodySigned(signedURL, headers)
+		if err == nil && status >= 200 && status < 300 {
+			result.Permissions = "AUTHENTICATED_READ_CONFIRMED"
+			return
+		}
+	}
+}
+
+// checkAuthenticatedWrite promotes a 403 further still: it signs a PUT
+// of a zero-byte, uniquely-named probe object and, if that succeeds,
+// immediately signs a DELETE of the same key to clean up. A successful
+// PUT means WRITE (or FULL_CONTROL via ACL/bucket policy) is granted to
+// any authenticated AWS principal, not just the bucket owner - a
+// stronger finding than checkAuthenticatedRead's read-only confirmation,
+// so it always overwrites result.Permissions when it fires. The DELETE's
+// own success or failure doesn't change the verdict; it's best-effort
+// tidy-up, not part of the write signal.
+func (p *S3ProviderV2) checkAuthenticatedWrite(target string, result *core.Result) {
+	creds := p.awsCredentials()
+	if creds == nil {
+		return
+	}
+
+	region := p.detectRegion(target)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	probeURL := fmt.Sprintf("%s/.skyscan-write-probe-%d", target, time.Now().UnixNano())
+
+	putHeaders, err := signRequest(http.MethodPut, probeURL, region, *creds)
+	if err != nil {
+		return
+	}
+	_, status, err := p.httpClient.SignedDo(http.MethodPut, probeURL, putHeaders, nil)
+	if err != nil || status < 200 || status >= 300 {
+		return
+	}
+
+	result.Permissions = "AUTHENTICATED_WRITE_CONFIRMED"
+
+	if delHeaders, err := signRequest(http.MethodDelete, probeURL, region, *creds); err == nil {
+		p.httpClient.SignedDo(http.MethodDelete, probeURL, delHeaders, nil)
+	}
+}
+
+// awsCredentials resolves AWS credentials once per provider instance
+// (profile, then env, then EC2 IMDS) and caches the result, including
+// the "nothing configured" case, so a scan doesn't re-probe IMDS for
+// every 403.
+func (p *S3ProviderV2) awsCredentials() *Credentials {
+	p.credsOnce.Do(func() {
+		creds, err := ResolveCredentials(p.config.AWSProfile)
+		if err == nil {
+			p.creds = creds
+		}
+	})
+	return p.creds
+}
+
+// harvestBucketConfig runs CheckWithACL against bucketName and copies
+// its Severity onto result, additionally enumerating object versions
+// (ListVersions) when the ACL shows a public read grant or the bucket
+// configuration shows versioning enabled - the two signals the caller
+// would otherwise have to re-derive to know whether ListVersions is
+// worth the extra pagination.
+func (p *S3ProviderV2) harvestBucketConfig(ctx context.Context, bucketName string, result *core.Result) {
+	acl := p.CheckWithACL(ctx, bucketName)
+	result.Severity = acl.Severity
+
+	if acl.PublicRead || acl.VersioningEnabled {
+		var versionsTruncated bool
+		result.Versions, result.DeleteMarkerHidesData, versionsTruncated = p.ListVersions(ctx, bucketName)
+		result.Truncated = result.Truncated || versionsTruncated
+	}
+}
+
+// CheckWithACL performs deep ACL and bucket-configuration analysis
+// (requires a valid bucket). Every sub-resource beyond ?acl is a
+// best-effort anonymous GET: most are only readable anonymously on a
+// misconfigured bucket, so a failed/unparsed fetch just leaves its
+// fields zero-valued instead of erroring the whole result.
 func (p *S3ProviderV2) CheckWithACL(ctx context.Context, bucketName string) *S3ACLResult {
 	result := &S3ACLResult{
 		BucketName: bucketName,
 	}
+	base := fmt.Sprintf("http://%s.s3.amazonaws.com", bucketName)
 
 	// Check for public listing (/?acl suffix)
-	aclURL := fmt.Sprintf("http://%s.s3.amazonaws.com/?acl", bucketName)
-	body, err := p.httpClient.GetBody(aclURL)
+	body, err := p.httpClient.GetBody(base + "/?acl")
 	if err != nil {
 		result.Error = err.Error()
 		return result
@@ -199,21 +468,136 @@ func (p *S3ProviderV2) CheckWithACL(ctx context.Context, bucketName string) *S3A
 	if err := xml.Unmarshal(body, &acl); err == nil {
 		for _, grant := range acl.AccessControlList.Grant {
 			grantee := grant.Grantee.URI
-			if strings.Contains(grantee, "AllUsers") {
-				result.PublicRead = true
-				if grant.Permission == "WRITE" {
+			switch {
+			case strings.Contains(grantee, "AllUsers"):
+				switch grant.Permission {
+				case "READ", "FULL_CONTROL":
+					result.PublicRead = true
+				case "WRITE":
+					result.PublicWrite = true
+				case "READ_ACP":
+					result.PublicReadACP = true
+				case "WRITE_ACP":
+					result.PublicWriteACP = true
+				}
+				if grant.Permission == "FULL_CONTROL" {
 					result.PublicWrite = true
+					result.PublicReadACP = true
+					result.PublicWriteACP = true
+				}
+			case strings.Contains(grantee, "AuthenticatedUsers"):
+				switch grant.Permission {
+				case "READ", "FULL_CONTROL":
+					result.AuthenticatedRead = true
+				case "WRITE":
+					result.AuthenticatedWrite = true
+				case "READ_ACP":
+					result.AuthenticatedReadACP = true
+				case "WRITE_ACP":
+					result.AuthenticatedWriteACP = true
+				}
+				if grant.Permission == "FULL_CONTROL" {
+					result.AuthenticatedWrite = true
+					result.AuthenticatedReadACP = true
+					result.AuthenticatedWriteACP = true
 				}
 			}
-			if strings.Contains(grantee, "AuthenticatedUsers") {
-				result.AuthenticatedRead = true
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?policy"); err == nil {
+		var policy BucketPolicy
+		if json.Unmarshal(body, &policy) == nil {
+			result.Policy = &policy
+			result.PolicyAllowsWildcardPrincipal = policy.allowsWildcardPrincipal()
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?policyStatus"); err == nil {
+		var status PolicyStatus
+		if xml.Unmarshal(body, &status) == nil {
+			result.PolicyStatus = &status
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?cors"); err == nil {
+		var cors CORSConfiguration
+		if xml.Unmarshal(body, &cors) == nil {
+			result.CORS = &cors
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?website"); err == nil {
+		var website WebsiteConfiguration
+		if xml.Unmarshal(body, &website) == nil {
+			result.Website = &website
+			result.WebsiteHostingEnabled = true
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?logging"); err == nil {
+		var logging BucketLoggingStatus
+		if xml.Unmarshal(body, &logging) == nil {
+			result.Logging = &logging
+			result.LoggingDisabled = logging.LoggingEnabled == nil
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?versioning"); err == nil {
+		var versioning VersioningConfiguration
+		if xml.Unmarshal(body, &versioning) == nil {
+			result.Versioning = &versioning
+			result.VersioningEnabled = versioning.Status == "Enabled"
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?encryption"); err == nil {
+		var sse ServerSideEncryptionConfiguration
+		if xml.Unmarshal(body, &sse) == nil {
+			result.Encryption = &sse
+			result.SSEDisabled = len(sse.Rules) == 0
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?lifecycle"); err == nil {
+		var lifecycle LifecycleConfiguration
+		if xml.Unmarshal(body, &lifecycle) == nil {
+			result.Lifecycle = &lifecycle
+		}
+	}
+
+	if body, err := p.httpClient.GetBody(base + "/?tagging"); err == nil {
+		var tagging Tagging
+		if xml.Unmarshal(body, &tagging) == nil && len(tagging.TagSet.Tag) > 0 {
+			result.Tags = make(map[string]string, len(tagging.TagSet.Tag))
+			for _, tag := range tagging.TagSet.Tag {
+				result.Tags[tag.Key] = tag.Value
 			}
 		}
 	}
 
+	result.Severity = result.severity()
 	return result
 }
 
+// severity scores an S3ACLResult's findings so a bucket that only
+// exposes configuration (no object read) can still surface as
+// high-risk: a world-writable policy or ACL is the worst case, a
+// world-readable one is still bad, and a misconfiguration like
+// disabled access logging or SSE is a lesser but real finding.
+func (r *S3ACLResult) severity() string {
+	switch {
+	case r.PublicWrite || r.AuthenticatedWrite || r.PolicyAllowsWildcardPrincipal:
+		return "CRITICAL"
+	case r.PublicRead || r.AuthenticatedRead || r.PublicWriteACP || r.AuthenticatedWriteACP:
+		return "HIGH"
+	case r.WebsiteHostingEnabled || r.LoggingDisabled || r.SSEDisabled:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
 // detectRegion attempts to detect the bucket's region via HEAD request
 func (p *S3ProviderV2) detectRegion(target string) string {
 	// S3 returns x-amz-bucket-region header on 301/307 redirects
@@ -230,13 +614,18 @@ func (p *S3ProviderV2) detectRegion(target string) string {
 	}
 	p.mu.RUnlock()
 
-	// Make HEAD request to global endpoint
-	url := fmt.Sprintf("http://%s.s3.amazonaws.com", bucketName)
+	// Make HEAD request to global endpoint, sharing this provider's
+	// per-host rate limit/pacer with everything routed through
+	// p.httpClient instead of firing unthrottled.
+	host := bucketName + ".s3.amazonaws.com"
+	url := fmt.Sprintf("http://%s", host)
+	p.httpClient.Pace(host)
 	resp, err := http.Head(url)
 	if err != nil {
 		return ""
 	}
 	defer resp.Body.Close()
+	p.httpClient.RecordPacerOutcome(host, resp.StatusCode == 429 || resp.StatusCode == 503)
 
 	region := resp.Header.Get("x-amz-bucket-region")
 	if region != "" {
@@ -272,13 +661,160 @@ func extractBucketName(url string) string {
 	return ""
 }
 
-// S3ACLResult holds detailed ACL analysis
+// S3ACLResult holds detailed ACL and bucket-configuration analysis.
+// Every *Configuration/Policy/Status field is nil when that
+// sub-resource wasn't readable anonymously or didn't parse; the
+// derived booleans alongside them are only meaningful when non-nil.
 type S3ACLResult struct {
-	BucketName        string
-	PublicRead        bool
-	PublicWrite       bool
-	AuthenticatedRead bool
-	Error             string
+	BucketName string
+
+	// PublicRead/PublicWrite/PublicReadACP/PublicWriteACP reflect the
+	// AllUsers grantee's READ/WRITE/READ_ACP/WRITE_ACP grants in the
+	// bucket ACL; the AuthenticatedXxx fields are the same four
+	// permissions for the AuthenticatedUsers grantee (any AWS principal,
+	// not just this bucket's owner).
+	PublicRead            bool
+	PublicWrite           bool
+	PublicReadACP         bool
+	PublicWriteACP        bool
+	AuthenticatedRead     bool
+	AuthenticatedWrite    bool
+	AuthenticatedReadACP  bool
+	AuthenticatedWriteACP bool
+	Error                 string
+
+	Policy                        *BucketPolicy
+	PolicyStatus                  *PolicyStatus
+	CORS                          *CORSConfiguration
+	Website                       *WebsiteConfiguration
+	Logging                       *BucketLoggingStatus
+	Versioning                    *VersioningConfiguration
+	Encryption                    *ServerSideEncryptionConfiguration
+	Lifecycle                     *LifecycleConfiguration
+	Tags                          map[string]string
+	PolicyAllowsWildcardPrincipal bool
+	WebsiteHostingEnabled         bool
+	LoggingDisabled               bool
+	VersioningEnabled             bool
+	SSEDisabled                   bool
+	Severity                      string
+}
+
+// BucketPolicy is the JSON document returned by GET ?policy (S3
+// bucket policies are IAM-style JSON, unlike every other sub-resource
+// here which is XML).
+type BucketPolicy struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+type PolicyStatement struct {
+	Effect    string      `json:"Effect"`
+	Principal interface{} `json:"Principal"`
+	Action    interface{} `json:"Action"`
+	Resource  interface{} `json:"Resource"`
+}
+
+// allowsWildcardPrincipal reports whether any Allow statement grants
+// access to "*" or {"AWS": "*"}, i.e. any AWS principal (or the
+// public, for S3) rather than a specific account/role.
+func (p BucketPolicy) allowsWildcardPrincipal() bool {
+	for _, stmt := range p.Statement {
+		if !strings.EqualFold(stmt.Effect, "Allow") {
+			continue
+		}
+		switch principal := stmt.Principal.(type) {
+		case string:
+			if principal == "*" {
+				return true
+			}
+		case map[string]interface{}:
+			for _, v := range principal {
+				if v == "*" {
+					return true
+				}
+				if vs, ok := v.([]interface{}); ok {
+					for _, item := range vs {
+						if item == "*" {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// PolicyStatus is the XML shape of GET ?policyStatus.
+type PolicyStatus struct {
+	IsPublic bool `xml:"IsPublic"`
+}
+
+// CORSConfiguration is the XML shape of GET ?cors.
+type CORSConfiguration struct {
+	CORSRules []CORSRule `xml:"CORSRule"`
+}
+
+type CORSRule struct {
+	AllowedOrigin []string `xml:"AllowedOrigin"`
+	AllowedMethod []string `xml:"AllowedMethod"`
+	AllowedHeader []string `xml:"AllowedHeader"`
+}
+
+// WebsiteConfiguration is the XML shape of GET ?website.
+type WebsiteConfiguration struct {
+	IndexDocument *struct {
+		Suffix string `xml:"Suffix"`
+	} `xml:"IndexDocument"`
+	ErrorDocument *struct {
+		Key string `xml:"Key"`
+	} `xml:"ErrorDocument"`
+	RedirectAllRequestsTo *struct {
+		HostName string `xml:"HostName"`
+	} `xml:"RedirectAllRequestsTo"`
+}
+
+// BucketLoggingStatus is the XML shape of GET ?logging.
+type BucketLoggingStatus struct {
+	LoggingEnabled *struct {
+		TargetBucket string `xml:"TargetBucket"`
+		TargetPrefix string `xml:"TargetPrefix"`
+	} `xml:"LoggingEnabled"`
+}
+
+// VersioningConfiguration is the XML shape of GET ?versioning. Status
+// is "Enabled", "Suspended", or absent (never turned on).
+type VersioningConfiguration struct {
+	Status string `xml:"Status"`
+}
+
+// ServerSideEncryptionConfiguration is the XML shape of GET
+// ?encryption.
+type ServerSideEncryptionConfiguration struct {
+	Rules []struct {
+		ApplyServerSideEncryptionByDefault struct {
+			SSEAlgorithm string `xml:"SSEAlgorithm"`
+		} `xml:"ApplyServerSideEncryptionByDefault"`
+	} `xml:"Rule"`
+}
+
+// LifecycleConfiguration is the XML shape of GET ?lifecycle.
+type LifecycleConfiguration struct {
+	Rules []struct {
+		ID     string `xml:"ID"`
+		Status string `xml:"Status"`
+	} `xml:"Rule"`
+}
+
+// Tagging is the XML shape of GET ?tagging.
+type Tagging struct {
+	TagSet struct {
+		Tag []struct {
+			Key   string `xml:"Key"`
+			Value string `xml:"Value"`
+		} `xml:"Tag"`
+	} `xml:"TagSet"`
 }
 
 // XML structures for S3 responses
@@ -308,30 +844,93 @@ type Grantee struct {
 	DisplayName string `xml:"DisplayName"`
 }
 
-// Minimal XML parser for S3 ListBucketResult
-type ListBucketResultV2 struct {
+// listObjects follows the ListObjectsV2 continuation-token pagination
+// loop against target's virtual-hosted bucket endpoint, accumulating
+// object keys up to Config.MaxObjects/MaxPages. The returned bool
+// reports whether more objects existed beyond whichever cap was hit
+// first (or beyond S3's own pagination, impossible here since
+// IsTruncated=false ends the loop cleanly).
+func (p *S3ProviderV2) listObjects(target string) ([]string, bool) {
+	maxObjects := p.config.MaxObjects
+	if maxObjects <= 0 {
+		maxObjects = 1000
+	}
+	maxPages := p.config.MaxPages
+	if maxPages <= 0 {
+		maxPages = 10
+	}
+
+	var files []string
+	var token string
+	for page := 0; page < maxPages; page++ {
+		listURL := target + "/?list-type=2"
+		if token != "" {
+			listURL += "&continuation-token=" + url.QueryEscape(token)
+		}
+
+		body, err := p.httpClient.GetBody(listURL)
+		if err != nil {
+			return files, false
+		}
+
+		result, err := parseS3ListPage(body)
+		if err != nil {
+			return files, false
+		}
+		files = append(files, result.Files...)
+
+		if len(files) > maxObjects || (len(files) == maxObjects && result.IsTruncated) {
+			return files[:maxObjects], true
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, false
+		}
+		token = result.NextContinuationToken
+	}
+
+	return files, true // hit MaxPages with more pages still available
+}
+
+// s3ListPage is one parsed page of a ListObjectsV2 response.
+type s3ListPage struct {
+	Files                 []string
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// s3ListBucketResult is the XML shape of a ListObjectsV2 response.
+type s3ListBucketResult struct {
 	Contents []struct {
 		Key          string `xml:"Key"`
 		LastModified string `xml:"LastModified"`
 		Size         int64  `xml:"Size"`
 		StorageClass string `xml:"StorageClass"`
 	} `xml:"Contents"`
-	IsTruncated bool   `xml:"IsTruncated"`
-	NextMarker  string `xml:"NextContinuationToken"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
 }
 
-func parseS3XMLv2(body []byte) []string {
-	var result ListBucketResultV2
+func parseS3ListPage(body []byte) (s3ListPage, error) {
+	var result s3ListBucketResult
 	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil
+		return s3ListPage{}, err
 	}
 
-	var files []string
-	for i, item := range result.Contents {
-		if i >= 10 { // Limit to first 10 files
-			break
+	files := make([]string, 0, len(result.Contents))
+	for _, item := range result.Contents {
+		desc := fmt.Sprintf("%s (%d bytes", item.Key, item.Size)
+		if item.LastModified != "" {
+			desc += fmt.Sprintf(", modified %s", item.LastModified)
 		}
-		files = append(files, fmt.Sprintf("%s (%d bytes)", item.Key, item.Size))
+		if item.StorageClass != "" {
+			desc += fmt.Sprintf(", %s", item.StorageClass)
+		}
+		files = append(files, desc+")")
 	}
-	return files
+
+	return s3ListPage{
+		Files:                 files,
+		IsTruncated:           result.IsTruncated,
+		NextContinuationToken: result.NextContinuationToken,
+	}, nil
 }
@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+)
+
+// s3Version describes one entry from a ListVersionsResult: either a
+// <Version> (an actual object revision) or a <DeleteMarker> (a
+// tombstone that hides the key from the current listing without
+// removing any prior version's data).
+type s3Version struct {
+	Key          string
+	VersionID    string
+	IsLatest     bool
+	Size         int64
+	LastModified string
+	DeleteMarker bool
+}
+
+// s3ListVersionsResult is the XML shape of GET ?versions. Version and
+// DeleteMarker are separate repeating elements interleaved in
+// S3's actual response; Go's encoding/xml collects each into its own
+// slice, which loses that interleaving, but ListVersions only needs
+// per-Key grouping, not full document order.
+type s3ListVersionsResult struct {
+	IsTruncated         bool   `xml:"IsTruncated"`
+	NextKeyMarker       string `xml:"NextKeyMarker"`
+	NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+	Version             []struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		Size         int64  `xml:"Size"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"Version"`
+	DeleteMarker []struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"DeleteMarker"`
+}
+
+// ListVersions enumerates every object version and delete-marker in
+// bucketName via anonymous GET /?versions, paginating with
+// key-marker/version-id-marker up to Config.MaxObjects/MaxPages like
+// listObjects. It reports one description string per entry and
+// whether any key has a non-latest Version still sitting behind a
+// DeleteMarker - data that looks deleted in a normal listing but is
+// still publicly GETtable by VersionId.
+func (p *S3ProviderV2) ListVersions(ctx context.Context, bucketName string) (versions []string, deleteMarkerHidesData bool, truncated bool) {
+	maxObjects := p.config.MaxObjects
+	if maxObjects <= 0 {
+		maxObjects = 1000
+	}
+	maxPages := p.config.MaxPages
+	if maxPages <= 0 {
+		maxPages = 10
+	}
+
+	base := fmt.Sprintf("http://%s.s3.amazonaws.com", bucketName)
+
+	hasDeleteMarker := make(map[string]bool)
+	hasOlderVersion := make(map[string]bool)
+
+	var keyMarker, versionIDMarker string
+	for page := 0; page < maxPages; page++ {
+		select {
+		case <-ctx.Done():
+			return versions, deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), true
+		default:
+		}
+
+		listURL := base + "/?versions&max-keys=1000"
+		if keyMarker != "" {
+			listURL += "&key-marker=" + url.QueryEscape(keyMarker)
+		}
+		if versionIDMarker != "" {
+			listURL += "&version-id-marker=" + url.QueryEscape(versionIDMarker)
+		}
+
+		body, err := p.httpClient.GetBody(listURL)
+		if err != nil {
+			return versions, deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), false
+		}
+
+		var result s3ListVersionsResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return versions, deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), false
+		}
+
+		for _, v := range result.Version {
+			if !v.IsLatest {
+				hasOlderVersion[v.Key] = true
+			}
+			desc := fmt.Sprintf("%s version=%s (%d bytes, modified %s)", v.Key, v.VersionID, v.Size, v.LastModified)
+			versions = append(versions, desc)
+		}
+		for _, dm := range result.DeleteMarker {
+			hasDeleteMarker[dm.Key] = true
+			versions = append(versions, fmt.Sprintf("%s version=%s (delete marker, modified %s)", dm.Key, dm.VersionID, dm.LastModified))
+		}
+
+		if len(versions) >= maxObjects {
+			return versions[:maxObjects], deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), true
+		}
+		if !result.IsTruncated {
+			return versions, deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), false
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+
+	return versions, deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion), true
+}
+
+// deleteMarkerHidesDataFrom reports whether any key has both a delete
+// marker and an older version still listed, i.e. the key reads as
+// deleted but a prior revision is still fetchable by VersionId.
+func deleteMarkerHidesDataFrom(hasDeleteMarker, hasOlderVersion map[string]bool) bool {
+	for key := range hasDeleteMarker {
+		if hasOlderVersion[key] {
+			return true
+		}
+	}
+	return false
+}
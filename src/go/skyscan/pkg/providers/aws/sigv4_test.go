@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalURI(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/my-bucket", "/my-bucket"},
+	}
+	for _, tt := range tests {
+		if got := canonicalURI(tt.path); got != tt.want {
+			t.Errorf("canonicalURI(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalQueryString(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"empty", "", ""},
+		{"bare key renders as key=", "acl", "acl="},
+		{"sorted by key", "b=2&a=1", "a=1&b=2"},
+		{"sorted by value within a key", "tag=b&tag=a", "tag=a&tag=b"},
+		{"percent-encodes reserved characters", "prefix=a b", "prefix=a%20b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonicalQueryString(tt.raw); got != tt.want {
+				t.Errorf("canonicalQueryString(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeHeaders(t *testing.T) {
+	// signRequest always builds this map with already-lowercased keys;
+	// canonicalizeHeaders only lowercases the sort order, not the
+	// per-key lookup, so a mixed-case key here wouldn't resolve.
+	headers := map[string]string{
+		"x-amz-date": "20230101T000000Z",
+		"host":       "bucket.s3.amazonaws.com",
+	}
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(headers)
+
+	if signedHeaders != "host;x-amz-date" {
+		t.Errorf("signedHeaders = %q, want %q", signedHeaders, "host;x-amz-date")
+	}
+
+	want := "host:bucket.s3.amazonaws.com\nx-amz-date:20230101T000000Z\n"
+	if canonicalHeaders != want {
+		t.Errorf("canonicalHeaders = %q, want %q", canonicalHeaders, want)
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}
+
+	headers, err := signRequest("GET", "https://my-bucket.s3.amazonaws.com/?acl", "us-east-1", creds)
+	if err != nil {
+		t.Fatalf("signRequest returned error: %v", err)
+	}
+
+	auth := headers["Authorization"]
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Errorf("Authorization header has unexpected prefix: %q", auth)
+	}
+	if !strings.Contains(auth, "/us-east-1/s3/aws4_request, ") {
+		t.Errorf("Authorization header missing expected scope: %q", auth)
+	}
+	if !strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") {
+		t.Errorf("Authorization header has unexpected SignedHeaders: %q", auth)
+	}
+
+	if _, ok := headers["x-amz-security-token"]; ok {
+		t.Errorf("x-amz-security-token should be absent without a SessionToken")
+	}
+}
+
+func TestSignRequestIncludesSessionToken(t *testing.T) {
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "token"}
+
+	headers, err := signRequest("GET", "https://my-bucket.s3.amazonaws.com/", "us-east-1", creds)
+	if err != nil {
+		t.Fatalf("signRequest returned error: %v", err)
+	}
+
+	if headers["x-amz-security-token"] != "token" {
+		t.Errorf("x-amz-security-token = %q, want %q", headers["x-amz-security-token"], "token")
+	}
+	if !strings.Contains(headers["Authorization"], "x-amz-security-token") {
+		t.Errorf("SignedHeaders should include x-amz-security-token when a session token is present: %q", headers["Authorization"])
+	}
+}
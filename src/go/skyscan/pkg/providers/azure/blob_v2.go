@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"skyscan/pkg/core"
 	"skyscan/pkg/net"
+	"skyscan/pkg/providers"
 	"strings"
 )
 
@@ -18,6 +19,46 @@ type AzureBlobProviderV2 struct {
 	httpClient  *net.Client
 	dnsResolver *net.DNSResolver
 	config      *core.Config
+
+	// endpointModel supplies the dnsSuffix for sovereign partitions
+	// (see partitions()), loaded in Init from config.EndpointsPath.
+	endpointModel *providers.EndpointModel
+}
+
+// azurePartitionSuffix maps a pkg/providers.EndpointModel partition id
+// to the blob-storage DNS suffix Generate/Check substitute into
+// "{account}.blob.{dnsSuffix}".
+type azurePartitionSuffix struct {
+	ID        string
+	DNSSuffix string
+}
+
+// defaultAzurePartition is always enumerated, matching the provider's
+// pre-partition-support behavior.
+var defaultAzurePartition = azurePartitionSuffix{ID: "azure", DNSSuffix: "core.windows.net"}
+
+// partitions returns defaultAzurePartition plus any sovereign Azure
+// partition named in Config.Partitions (azure-china, azure-us-gov,
+// azure-germany), resolved against p.endpointModel. Unrecognized or
+// non-Azure partition names are ignored, so target counts don't
+// explode unless the caller opts in.
+func (p *AzureBlobProviderV2) partitions() []azurePartitionSuffix {
+	suffixes := []azurePartitionSuffix{defaultAzurePartition}
+
+	if p.config == nil || p.endpointModel == nil {
+		return suffixes
+	}
+	for _, id := range p.config.Partitions {
+		if id == "" || id == "azure" || !strings.HasPrefix(id, "azure") {
+			continue
+		}
+		partition, ok := p.endpointModel.Partition(id)
+		if !ok {
+			continue
+		}
+		suffixes = append(suffixes, azurePartitionSuffix{ID: id, DNSSuffix: partition.DNSSuffix})
+	}
+	return suffixes
 }
 
 // Common Azure container names for brute-forcing
@@ -61,51 +102,74 @@ func (p *AzureBlobProviderV2) Name() string {
 
 func (p *AzureBlobProviderV2) Init(config *core.Config) error {
 	p.config = config
+
+	model, err := providers.LoadEndpointModel(config.EndpointsPath)
+	if err != nil {
+		return fmt.Errorf("load endpoint model: %w", err)
+	}
+	p.endpointModel = model
 	return nil
 }
 
-// Generate creates candidate Azure Storage URLs
+// Generate creates candidate Azure Storage URLs, one set per partition
+// returned by p.partitions() (commercial Azure plus any sovereign
+// partitions named in Config.Partitions). Storage account names are
+// 3-24 lowercase alphanumerics, so a keyword outside that rule (and
+// every mutation built from it) is skipped rather than generating URLs
+// that can never resolve.
 func (p *AzureBlobProviderV2) Generate(ctx context.Context, keyword string, output chan<- string) {
-	// Azure Storage account endpoint
-	accountDomain := fmt.Sprintf("%s.blob.core.windows.net", keyword)
-
-	// First, just the account (to check if it exists)
-	select {
-	case <-ctx.Done():
+	if !providers.AzureStorageAccountNameRule.Match(keyword) {
 		return
-	case output <- fmt.Sprintf("http://%s", accountDomain):
 	}
 
-	// Then, enumerate containers
-	for _, container := range commonContainers {
+	for _, partition := range p.partitions() {
+		accountDomain := fmt.Sprintf("%s.blob.%s", keyword, partition.DNSSuffix)
+
+		// First, just the account (to check if it exists)
 		select {
 		case <-ctx.Done():
 			return
-		case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, container):
+		case output <- fmt.Sprintf("http://%s", accountDomain):
 		}
-	}
 
-	// Keyword-based container names
-	for _, mut := range p.config.Mutations {
-		select {
-		case <-ctx.Done():
-			return
-		case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, keyword+"-"+mut):
-		case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, mut+"-"+keyword):
+		// Then, enumerate containers. Check's active list-containers
+		// call (see discoverContainers) usually finds these directly,
+		// so this wordlist - the built-in list plus Config.ContainerWordlist
+		// - only matters once anonymous listing is denied.
+		containers := commonContainers
+		if len(p.config.ContainerWordlist) > 0 {
+			containers = append(append([]string{}, commonContainers...), p.config.ContainerWordlist...)
+		}
+		for _, container := range containers {
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, container):
+			}
+		}
+
+		// Keyword-based container names
+		for _, mut := range p.config.Mutations {
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, keyword+"-"+mut):
+			case output <- fmt.Sprintf("http://%s/%s?restype=container&comp=list", accountDomain, mut+"-"+keyword):
+			}
 		}
 	}
 }
 
 // Check validates an Azure Storage URL
 func (p *AzureBlobProviderV2) Check(ctx context.Context, target string) (*core.Result, error) {
-	// Extract account and container from URL
-	accountName, containerName := extractAzureInfo(target)
+	// Extract account, partition DNS suffix, and container from URL
+	accountName, dnsSuffix, containerName := extractAzureInfo(target)
 	if accountName == "" {
 		return nil, fmt.Errorf("could not extract account from %s", target)
 	}
 
 	// Phase 1: DNS Check for storage account existence
-	dnsHost := fmt.Sprintf("%s.blob.core.windows.net", accountName)
+	dnsHost := fmt.Sprintf("%s.blob.%s", accountName, dnsSuffix)
 	dnsResult := p.dnsResolver.CheckExists(ctx, dnsHost)
 
 	if dnsResult.Error != nil {
@@ -121,7 +185,19 @@ func (p *AzureBlobProviderV2) Check(ctx context.Context, target string) (*core.R
 		return nil, nil
 	}
 
-	// Phase 2: HTTP Check for container access
+	// Phase 2: for the bare account target (no container in the URL),
+	// try anonymous list-containers before falling back to the
+	// wordlist-driven container targets Generate also emitted. This
+	// finds containers the wordlist doesn't know about; the wordlist
+	// targets still get checked independently if this comes back
+	// 403/AuthenticationFailed.
+	if containerName == "" {
+		if result := p.discoverContainers(target, dnsHost); result != nil {
+			return result, nil
+		}
+	}
+
+	// Phase 3: HTTP Check for container access
 	// Use restype=container&comp=list for proper Azure API
 	checkURL := target
 	if containerName != "" && !strings.Contains(target, "restype=container") {
@@ -170,14 +246,102 @@ func (p *AzureBlobProviderV2) Check(ctx context.Context, target string) (*core.R
 	return result, nil
 }
 
+// maxHarvestedContainers caps how many containers discoverContainers
+// will issue a per-container blob listing against, so a storage
+// account with hundreds of public containers doesn't turn one finding
+// into hundreds of requests.
+const maxHarvestedContainers = 20
+
+// maxBlobsPerContainer caps how many blobs discoverContainers records
+// per container into Result.Extra, matching parseAzureBlobXML's limit
+// for the wordlist-driven path.
+const maxBlobsPerContainer = 10
+
+// discoverContainers issues an anonymous list-containers call
+// (GET /?comp=list) against dnsHost and, on success, harvests the
+// first page of blobs from each of the first maxHarvestedContainers
+// containers it finds (setting Truncated if there are more). It returns nil
+// (not an error) when the call isn't a 200 - AuthenticationFailed/403
+// just means Check should fall back to the wordlist-driven container
+// targets Generate also emitted for this account.
+func (p *AzureBlobProviderV2) discoverContainers(target, dnsHost string) *core.Result {
+	listURL := fmt.Sprintf("http://%s/?comp=list", dnsHost)
+	body, status, err := p.httpClient.GetBodySigned(listURL, nil)
+	if err != nil || status != 200 {
+		return nil
+	}
+
+	var parsed ContainerEnumerationResults
+	if xml.Unmarshal(body, &parsed) != nil {
+		return nil
+	}
+
+	var containerNames []string
+	for _, c := range parsed.Containers.Container {
+		containerNames = append(containerNames, c.Name)
+	}
+	if len(containerNames) == 0 {
+		return nil
+	}
+
+	result := &core.Result{
+		URL:         target,
+		Provider:    "Azure",
+		Status:      status,
+		Permissions: "PUBLIC_LIST_CONTAINERS",
+		Files:       containerNames,
+		Extra:       make(map[string]string),
+	}
+
+	harvestCount := len(containerNames)
+	if harvestCount > maxHarvestedContainers {
+		harvestCount = maxHarvestedContainers
+		result.Truncated = true
+	}
+	for _, container := range containerNames[:harvestCount] {
+		p.harvestContainerBlobs(dnsHost, container, result.Extra)
+	}
+
+	return result
+}
+
+// harvestContainerBlobs lists container's first page of blobs and
+// records up to maxBlobsPerContainer of them into extra as
+// "container:blob" -> "size bytes", the same shape
+// AzureBlobProviderV2's per-container wordlist Check reports via
+// Result.Files but keyed so findings from multiple containers don't
+// collide in one map.
+func (p *AzureBlobProviderV2) harvestContainerBlobs(dnsHost, container string, extra map[string]string) {
+	listURL := fmt.Sprintf("http://%s/%s?restype=container&comp=list", dnsHost, container)
+	status, _, err := p.httpClient.Check(listURL)
+	if err != nil || status != 200 {
+		return
+	}
+	body, err := p.httpClient.GetBody(listURL)
+	if err != nil {
+		return
+	}
+
+	var parsed EnumerationResults
+	if xml.Unmarshal(body, &parsed) != nil {
+		return
+	}
+	for i, blob := range parsed.Blobs.Blob {
+		if i >= maxBlobsPerContainer {
+			break
+		}
+		extra[container+":"+blob.Name] = fmt.Sprintf("%d bytes", blob.Properties.ContentLength)
+	}
+}
+
 // ListContainersWithPagination handles Azure's pagination for large containers
 func (p *AzureBlobProviderV2) ListContainersWithPagination(accountName string, containerName string) ([]string, error) {
 	var allBlobs []string
 	marker := ""
 
 	for {
-		url := fmt.Sprintf("http://%s.blob.core.windows.net/%s?restype=container&comp=list",
-			accountName, containerName)
+		url := fmt.Sprintf("http://%s.blob.%s/%s?restype=container&comp=list",
+			accountName, defaultAzurePartition.DNSSuffix, containerName)
 		if marker != "" {
 			url += "&marker=" + marker
 		}
@@ -206,27 +370,43 @@ func (p *AzureBlobProviderV2) ListContainersWithPagination(accountName string, c
 	return allBlobs, nil
 }
 
-// Helper to extract account and container from Azure URL
-func extractAzureInfo(url string) (account, container string) {
+// Helper to extract account, blob-storage DNS suffix, and container
+// from an Azure URL. The suffix is recovered rather than assumed to be
+// core.windows.net so Check works against any partition Generate built
+// a URL for (see partitions()).
+func extractAzureInfo(url string) (account, dnsSuffix, container string) {
 	url = strings.TrimPrefix(url, "http://")
 	url = strings.TrimPrefix(url, "https://")
 
-	// Format: {account}.blob.core.windows.net/{container}
-	if strings.Contains(url, ".blob.core.windows.net") {
-		parts := strings.Split(url, ".blob.core.windows.net")
-		if len(parts) > 0 {
-			account = parts[0]
-		}
-		if len(parts) > 1 {
-			pathParts := strings.Split(strings.Trim(parts[1], "/"), "/")
-			if len(pathParts) > 0 && pathParts[0] != "" {
-				container = strings.Split(pathParts[0], "?")[0]
-			}
-		}
+	// Format: {account}.blob.{dnsSuffix}/{container}
+	const marker = ".blob."
+	idx := strings.Index(url, marker)
+	if idx == -1 {
+		return "", "", ""
+	}
+	account = url[:idx]
+
+	rest := url[idx+len(marker):]
+	pathParts := strings.SplitN(rest, "/", 2)
+	dnsSuffix = pathParts[0]
+	if len(pathParts) > 1 {
+		container = strings.Split(pathParts[1], "?")[0]
 	}
 	return
 }
 
+// ContainerEnumerationResults is the response body of an account-level
+// GET /?comp=list (list-containers), as opposed to EnumerationResults
+// below, which is a container-level GET /{container}?comp=list
+// (list-blobs).
+type ContainerEnumerationResults struct {
+	Containers struct {
+		Container []struct {
+			Name string `xml:"Name"`
+		} `xml:"Container"`
+	} `xml:"Containers"`
+}
+
 // Azure Blob XML structures
 type EnumerationResults struct {
 	Blobs      Blobs  `xml:"Blobs"`
@@ -0,0 +1,102 @@
+package providers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"skyscan/pkg/net"
+)
+
+// azureShareListResults is the response body of an anonymous
+// GET /?comp=list against a {account}.file.core.windows.net endpoint
+// (list file shares).
+type azureShareListResults struct {
+	Shares struct {
+		Share []struct {
+			Name string `xml:"Name"`
+		} `xml:"Share"`
+	} `xml:"Shares"`
+}
+
+// azureQueueListResults is the response body of an anonymous
+// GET /?comp=list against a {account}.queue.core.windows.net endpoint
+// (list queues).
+type azureQueueListResults struct {
+	Queues struct {
+		Queue []struct {
+			Name string `xml:"Name"`
+		} `xml:"Queue"`
+	} `xml:"Queues"`
+}
+
+// azureTableListResults is the response body of an anonymous
+// GET /Tables against a {account}.table.core.windows.net endpoint
+// (list tables), requested with an OData JSON Accept header rather
+// than Table Storage's default Atom/XML.
+type azureTableListResults struct {
+	Value []struct {
+		TableName string `json:"TableName"`
+	} `json:"value"`
+}
+
+// listAzureStorageSibling actively enumerates file shares, queues, or
+// tables on an Azure Storage account - the same anonymous-listing
+// approach azure.AzureBlobProviderV2 takes for blob containers,
+// applied to the three sibling endpoints AllCloudServices declares
+// (File Share/Queue Storage/Table Storage) that CheckTarget's generic
+// status-code check would otherwise leave at "reachable". serviceType
+// must be one of those three CloudService.ServiceType values; any
+// other value (or a non-200/unparseable response) returns (nil, false).
+func listAzureStorageSibling(client *net.Client, hostname, serviceType string) ([]string, bool) {
+	switch serviceType {
+	case "File Share":
+		body, status, err := client.GetBodySigned(fmt.Sprintf("http://%s/?comp=list", hostname), nil)
+		if err != nil || status != 200 {
+			return nil, false
+		}
+		var parsed azureShareListResults
+		if xml.Unmarshal(body, &parsed) != nil {
+			return nil, false
+		}
+		names := make([]string, 0, len(parsed.Shares.Share))
+		for _, s := range parsed.Shares.Share {
+			names = append(names, s.Name)
+		}
+		return names, len(names) > 0
+
+	case "Queue Storage":
+		body, status, err := client.GetBodySigned(fmt.Sprintf("http://%s/?comp=list", hostname), nil)
+		if err != nil || status != 200 {
+			return nil, false
+		}
+		var parsed azureQueueListResults
+		if xml.Unmarshal(body, &parsed) != nil {
+			return nil, false
+		}
+		names := make([]string, 0, len(parsed.Queues.Queue))
+		for _, q := range parsed.Queues.Queue {
+			names = append(names, q.Name)
+		}
+		return names, len(names) > 0
+
+	case "Table Storage":
+		body, status, err := client.GetBodySigned(fmt.Sprintf("http://%s/Tables", hostname), map[string]string{
+			"Accept": "application/json;odata=nometadata",
+		})
+		if err != nil || status != 200 {
+			return nil, false
+		}
+		var parsed azureTableListResults
+		if json.Unmarshal(body, &parsed) != nil {
+			return nil, false
+		}
+		names := make([]string, 0, len(parsed.Value))
+		for _, t := range parsed.Value {
+			names = append(names, t.TableName)
+		}
+		return names, len(names) > 0
+
+	default:
+		return nil, false
+	}
+}
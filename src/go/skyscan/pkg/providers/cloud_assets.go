@@ -15,227 +15,223 @@ type CloudService struct {
 	DomainPattern string   // e.g., "{keyword}.cloudfront.net"
 	Endpoints     []string // Specific endpoints to check
 	Severity      string   // Risk level if discovered
+
+	// Partition and ServiceKey name this service's entry in the
+	// endpoints.json model (see endpoints.go) for services whose
+	// hostname varies by region/partition. When both are set,
+	// GenerateAllTargets resolves the hostname through the loaded
+	// *EndpointModel instead of DomainPattern, so adding a region or a
+	// sovereign partition (aws-cn, azure-china, ...) is a JSON edit.
+	// Left empty for single-pattern services (most non-AWS/Azure/GCP
+	// providers), which still use DomainPattern directly.
+	Partition  string
+	ServiceKey string
+
+	// NameRule constrains which keywords/mutations are structurally
+	// possible for this service (see name_rules.go). GenerateAllTargets
+	// gates every emitted candidate on NameRule.Match so that, e.g., a
+	// 30-character hyphenated mutation never gets generated as an
+	// Azure Storage Account candidate (3-24 lowercase alnum).
+	NameRule NameRule
+
+	// Global marks a service with exactly one hostname regardless of
+	// region (e.g. CloudFront), so GenerateAllTargets emits one
+	// candidate for it instead of one per entry in
+	// EndpointResolver.Regions. Only meaningful for services that
+	// aren't Partition/ServiceKey-tagged; see endpoint_resolver.go.
+	Global bool
+
+	// RegionCoded marks a service whose {region} placeholder in
+	// DomainPattern should be substituted with a short region code
+	// (EndpointResolver.RegionCodeMap) rather than the region name
+	// itself, e.g. Cloud Run's "uc"/"ew"/"an".
+	RegionCoded bool
 }
 
 // AllCloudServices defines all targetable cloud services across providers
 var AllCloudServices = []CloudService{
 	// ============== AWS SERVICES ==============
 	// Content Delivery
-	{Provider: "AWS", ServiceType: "CloudFront", DomainPattern: "{keyword}.cloudfront.net", Severity: "MEDIUM"},
+	{Provider: "AWS", ServiceType: "CloudFront", DomainPattern: "{keyword}.cloudfront.net", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Compute
-	{Provider: "AWS", ServiceType: "Elastic Beanstalk", DomainPattern: "{keyword}.elasticbeanstalk.com", Severity: "HIGH"},
-	{Provider: "AWS", ServiceType: "App Runner", DomainPattern: "{keyword}.awsapprunner.com", Severity: "HIGH"},
-	{Provider: "AWS", ServiceType: "Lambda Function URL", DomainPattern: "{keyword}.lambda-url.{region}.on.aws", Severity: "HIGH"},
+	{Provider: "AWS", ServiceType: "Elastic Beanstalk", DomainPattern: "{keyword}.elasticbeanstalk.com", Severity: "HIGH", NameRule: genericNameRule},
+	// Real App Runner hostnames are regional; the old flat
+	// "{keyword}.awsapprunner.com" never resolved.
+	{Provider: "AWS", ServiceType: "App Runner", DomainPattern: "{keyword}.{region}.awsapprunner.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "Lambda Function URL", DomainPattern: "{keyword}.lambda-url.{region}.on.aws", Severity: "HIGH", Partition: "aws", ServiceKey: "lambda-url", NameRule: genericNameRule},
 
 	// API & Integration
-	{Provider: "AWS", ServiceType: "API Gateway", DomainPattern: "{keyword}.execute-api.{region}.amazonaws.com", Severity: "HIGH"},
-	{Provider: "AWS", ServiceType: "AppSync GraphQL", DomainPattern: "{keyword}.appsync-api.{region}.amazonaws.com", Severity: "HIGH"},
+	{Provider: "AWS", ServiceType: "API Gateway", DomainPattern: "{keyword}.execute-api.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "api-gateway", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "AppSync GraphQL", DomainPattern: "{keyword}.appsync-api.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "appsync", NameRule: genericNameRule},
 
 	// Containers
-	{Provider: "AWS", ServiceType: "EKS", DomainPattern: "{keyword}.eks.amazonaws.com", Severity: "CRITICAL"},
-	{Provider: "AWS", ServiceType: "ECS", DomainPattern: "{keyword}.ecs.{region}.amazonaws.com", Severity: "HIGH"},
-	{Provider: "AWS", ServiceType: "ECR Public", DomainPattern: "public.ecr.aws/{keyword}", Severity: "MEDIUM"},
+	{Provider: "AWS", ServiceType: "EKS", DomainPattern: "{keyword}.eks.amazonaws.com", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "ECS", DomainPattern: "{keyword}.ecs.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "ecs", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "ECR Public", DomainPattern: "public.ecr.aws/{keyword}", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Databases (exposed endpoints)
-	{Provider: "AWS", ServiceType: "RDS", DomainPattern: "{keyword}.{region}.rds.amazonaws.com", Severity: "CRITICAL"},
-	{Provider: "AWS", ServiceType: "Redshift", DomainPattern: "{keyword}.{region}.redshift.amazonaws.com", Severity: "CRITICAL"},
-	{Provider: "AWS", ServiceType: "DocumentDB", DomainPattern: "{keyword}.docdb.{region}.amazonaws.com", Severity: "CRITICAL"},
-	{Provider: "AWS", ServiceType: "ElastiCache", DomainPattern: "{keyword}.cache.amazonaws.com", Severity: "CRITICAL"},
+	{Provider: "AWS", ServiceType: "RDS", DomainPattern: "{keyword}.{region}.rds.amazonaws.com", Severity: "CRITICAL", Partition: "aws", ServiceKey: "rds", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "Redshift", DomainPattern: "{keyword}.{region}.redshift.amazonaws.com", Severity: "CRITICAL", Partition: "aws", ServiceKey: "redshift", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "DocumentDB", DomainPattern: "{keyword}.docdb.{region}.amazonaws.com", Severity: "CRITICAL", Partition: "aws", ServiceKey: "docdb", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "ElastiCache", DomainPattern: "{keyword}.cache.amazonaws.com", Severity: "CRITICAL", NameRule: genericNameRule},
 
 	// Messaging
-	{Provider: "AWS", ServiceType: "IoT Core", DomainPattern: "{keyword}.iot.{region}.amazonaws.com", Severity: "HIGH"},
-	{Provider: "AWS", ServiceType: "MQ", DomainPattern: "{keyword}.mq.{region}.amazonaws.com", Severity: "HIGH"},
+	{Provider: "AWS", ServiceType: "IoT Core", DomainPattern: "{keyword}.iot.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "iot", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "MQ", DomainPattern: "{keyword}.mq.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "mq", NameRule: genericNameRule},
 
 	// Media
-	{Provider: "AWS", ServiceType: "MediaPackage", DomainPattern: "{keyword}.mediapackage.{region}.amazonaws.com", Severity: "MEDIUM"},
-	{Provider: "AWS", ServiceType: "MediaStore", DomainPattern: "{keyword}.data.mediastore.{region}.amazonaws.com", Severity: "MEDIUM"},
+	{Provider: "AWS", ServiceType: "MediaPackage", DomainPattern: "{keyword}.mediapackage.{region}.amazonaws.com", Severity: "MEDIUM", Partition: "aws", ServiceKey: "mediapackage", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "MediaStore", DomainPattern: "{keyword}.data.mediastore.{region}.amazonaws.com", Severity: "MEDIUM", Partition: "aws", ServiceKey: "mediastore", NameRule: genericNameRule},
 
 	// Networking
-	{Provider: "AWS", ServiceType: "Global Accelerator", DomainPattern: "{keyword}.awsglobalaccelerator.com", Severity: "MEDIUM"},
-	{Provider: "AWS", ServiceType: "Transfer Family SFTP", DomainPattern: "{keyword}.transfer.{region}.amazonaws.com", Severity: "HIGH"},
+	{Provider: "AWS", ServiceType: "Global Accelerator", DomainPattern: "{keyword}.awsglobalaccelerator.com", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "Transfer Family SFTP", DomainPattern: "{keyword}.transfer.{region}.amazonaws.com", Severity: "HIGH", Partition: "aws", ServiceKey: "transfer", NameRule: genericNameRule},
 
 	// Developer Tools
-	{Provider: "AWS", ServiceType: "Amplify", DomainPattern: "{keyword}.amplifyapp.com", Severity: "MEDIUM"},
-	{Provider: "AWS", ServiceType: "CodeArtifact", DomainPattern: "{keyword}.codeartifact.{region}.amazonaws.com", Severity: "MEDIUM"},
+	{Provider: "AWS", ServiceType: "Amplify", DomainPattern: "{keyword}.amplifyapp.com", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "AWS", ServiceType: "CodeArtifact", DomainPattern: "{keyword}.codeartifact.{region}.amazonaws.com", Severity: "MEDIUM", Partition: "aws", ServiceKey: "codeartifact", NameRule: genericNameRule},
 
 	// ============== AZURE SERVICES ==============
 	// Compute
-	{Provider: "Azure", ServiceType: "App Service", DomainPattern: "{keyword}.azurewebsites.net", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Functions", DomainPattern: "{keyword}.azurewebsites.net", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Static Web Apps", DomainPattern: "{keyword}.azurestaticapps.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Cloud Services", DomainPattern: "{keyword}.cloudapp.azure.com", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Container Apps", DomainPattern: "{keyword}.containerapps.{region}.azurecontainerapps.io", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Spring Apps", DomainPattern: "{keyword}.azuremicroservices.io", Severity: "HIGH"},
+	{Provider: "Azure", ServiceType: "App Service", DomainPattern: "{keyword}.azurewebsites.net", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Functions", DomainPattern: "{keyword}.azurewebsites.net", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Static Web Apps", DomainPattern: "{keyword}.azurestaticapps.net", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Cloud Services", DomainPattern: "{keyword}.cloudapp.azure.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Container Apps", DomainPattern: "{keyword}.containerapps.{region}.azurecontainerapps.io", Severity: "HIGH", Partition: "azure", ServiceKey: "container-apps", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Spring Apps", DomainPattern: "{keyword}.azuremicroservices.io", Severity: "HIGH", NameRule: genericNameRule},
 
 	// API & Integration
-	{Provider: "Azure", ServiceType: "API Management", DomainPattern: "{keyword}.azure-api.net", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Logic Apps", DomainPattern: "{keyword}.logic.azure.com", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Event Grid", DomainPattern: "{keyword}.eventgrid.azure.net", Severity: "MEDIUM"},
+	{Provider: "Azure", ServiceType: "API Management", DomainPattern: "{keyword}.azure-api.net", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Logic Apps", DomainPattern: "{keyword}.logic.azure.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Event Grid", DomainPattern: "{keyword}.eventgrid.azure.net", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Containers
-	{Provider: "Azure", ServiceType: "Container Registry", DomainPattern: "{keyword}.azurecr.io", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "AKS", DomainPattern: "{keyword}.azmk8s.io", Severity: "CRITICAL"},
+	{Provider: "Azure", ServiceType: "Container Registry", DomainPattern: "{keyword}.azurecr.io", Severity: "HIGH", NameRule: acrNameRule},
+	{Provider: "Azure", ServiceType: "AKS", DomainPattern: "{keyword}.azmk8s.io", Severity: "CRITICAL", NameRule: genericNameRule},
 
 	// Databases
-	{Provider: "Azure", ServiceType: "SQL Database", DomainPattern: "{keyword}.database.windows.net", Severity: "CRITICAL"},
-	{Provider: "Azure", ServiceType: "CosmosDB", DomainPattern: "{keyword}.documents.azure.com", Severity: "CRITICAL"},
-	{Provider: "Azure", ServiceType: "MySQL", DomainPattern: "{keyword}.mysql.database.azure.com", Severity: "CRITICAL"},
-	{Provider: "Azure", ServiceType: "PostgreSQL", DomainPattern: "{keyword}.postgres.database.azure.com", Severity: "CRITICAL"},
-	{Provider: "Azure", ServiceType: "Redis Cache", DomainPattern: "{keyword}.redis.cache.windows.net", Severity: "CRITICAL"},
-	{Provider: "Azure", ServiceType: "MariaDB", DomainPattern: "{keyword}.mariadb.database.azure.com", Severity: "CRITICAL"},
+	{Provider: "Azure", ServiceType: "SQL Database", DomainPattern: "{keyword}.database.windows.net", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "CosmosDB", DomainPattern: "{keyword}.documents.azure.com", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "MySQL", DomainPattern: "{keyword}.mysql.database.azure.com", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "PostgreSQL", DomainPattern: "{keyword}.postgres.database.azure.com", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Redis Cache", DomainPattern: "{keyword}.redis.cache.windows.net", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "MariaDB", DomainPattern: "{keyword}.mariadb.database.azure.com", Severity: "CRITICAL", NameRule: genericNameRule},
 
 	// CDN & Networking
-	{Provider: "Azure", ServiceType: "CDN", DomainPattern: "{keyword}.azureedge.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Front Door", DomainPattern: "{keyword}.azurefd.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Traffic Manager", DomainPattern: "{keyword}.trafficmanager.net", Severity: "MEDIUM"},
+	{Provider: "Azure", ServiceType: "CDN", DomainPattern: "{keyword}.azureedge.net", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Front Door", DomainPattern: "{keyword}.azurefd.net", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Traffic Manager", DomainPattern: "{keyword}.trafficmanager.net", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Storage & File
-	{Provider: "Azure", ServiceType: "File Share", DomainPattern: "{keyword}.file.core.windows.net", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "Queue Storage", DomainPattern: "{keyword}.queue.core.windows.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Table Storage", DomainPattern: "{keyword}.table.core.windows.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Data Lake", DomainPattern: "{keyword}.dfs.core.windows.net", Severity: "HIGH"},
+	{Provider: "Azure", ServiceType: "File Share", DomainPattern: "{keyword}.file.core.windows.net", Severity: "HIGH", NameRule: AzureStorageAccountNameRule},
+	{Provider: "Azure", ServiceType: "Queue Storage", DomainPattern: "{keyword}.queue.core.windows.net", Severity: "MEDIUM", NameRule: AzureStorageAccountNameRule},
+	{Provider: "Azure", ServiceType: "Table Storage", DomainPattern: "{keyword}.table.core.windows.net", Severity: "MEDIUM", NameRule: AzureStorageAccountNameRule},
+	{Provider: "Azure", ServiceType: "Data Lake", DomainPattern: "{keyword}.dfs.core.windows.net", Severity: "HIGH", NameRule: AzureStorageAccountNameRule},
 
 	// DevOps & Developer
-	{Provider: "Azure", ServiceType: "DevOps", DomainPattern: "{keyword}.visualstudio.com", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "DevOps Artifacts", DomainPattern: "{keyword}.pkgs.visualstudio.com", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Key Vault", DomainPattern: "{keyword}.vault.azure.net", Severity: "CRITICAL"},
+	{Provider: "Azure", ServiceType: "DevOps", DomainPattern: "{keyword}.visualstudio.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "DevOps Artifacts", DomainPattern: "{keyword}.pkgs.visualstudio.com", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Key Vault", DomainPattern: "{keyword}.vault.azure.net", Severity: "CRITICAL", NameRule: genericNameRule},
 
 	// AI & ML
-	{Provider: "Azure", ServiceType: "Cognitive Services", DomainPattern: "{keyword}.cognitiveservices.azure.com", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "OpenAI", DomainPattern: "{keyword}.openai.azure.com", Severity: "HIGH"},
-	{Provider: "Azure", ServiceType: "ML Workspace", DomainPattern: "{keyword}.ml.azure.com", Severity: "HIGH"},
+	{Provider: "Azure", ServiceType: "Cognitive Services", DomainPattern: "{keyword}.cognitiveservices.azure.com", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "OpenAI", DomainPattern: "{keyword}.openai.azure.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "ML Workspace", DomainPattern: "{keyword}.ml.azure.com", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Communication
-	{Provider: "Azure", ServiceType: "SignalR", DomainPattern: "{keyword}.service.signalr.net", Severity: "MEDIUM"},
-	{Provider: "Azure", ServiceType: "Web PubSub", DomainPattern: "{keyword}.webpubsub.azure.com", Severity: "MEDIUM"},
+	{Provider: "Azure", ServiceType: "SignalR", DomainPattern: "{keyword}.service.signalr.net", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Azure", ServiceType: "Web PubSub", DomainPattern: "{keyword}.webpubsub.azure.com", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// ============== GCP SERVICES ==============
 	// Compute
-	{Provider: "GCP", ServiceType: "App Engine", DomainPattern: "{keyword}.appspot.com", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Cloud Run", DomainPattern: "{keyword}.run.app", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Cloud Functions", DomainPattern: "{region}-{keyword}.cloudfunctions.net", Severity: "HIGH"},
+	{Provider: "GCP", ServiceType: "App Engine", DomainPattern: "{keyword}.appspot.com", Severity: "HIGH", NameRule: genericNameRule},
+	// Real Cloud Run URLs also carry a per-revision hash
+	// ({keyword}-{hash}-{region-code}.a.run.app) that isn't derivable
+	// from the keyword, so the hash is omitted here; RegionCoded still
+	// gets the region segment right (a short code like "uc", not the
+	// full region name) via EndpointResolver.RegionCodeMap.
+	{Provider: "GCP", ServiceType: "Cloud Run", DomainPattern: "{keyword}-{region}.a.run.app", Severity: "HIGH", NameRule: genericNameRule, RegionCoded: true},
+	{Provider: "GCP", ServiceType: "Cloud Functions", DomainPattern: "{region}-{keyword}.cloudfunctions.net", Severity: "HIGH", Partition: "gcp", ServiceKey: "cloud-functions", NameRule: genericNameRule},
 
 	// Firebase
-	{Provider: "GCP", ServiceType: "Firebase Hosting", DomainPattern: "{keyword}.firebaseapp.com", Severity: "MEDIUM"},
-	{Provider: "GCP", ServiceType: "Firebase Hosting Alt", DomainPattern: "{keyword}.web.app", Severity: "MEDIUM"},
-	{Provider: "GCP", ServiceType: "Firebase RTDB", DomainPattern: "{keyword}.firebaseio.com", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Firebase Auth", DomainPattern: "{keyword}.firebaseapp.com/__/auth/handler", Severity: "MEDIUM"},
+	{Provider: "GCP", ServiceType: "Firebase Hosting", DomainPattern: "{keyword}.firebaseapp.com", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Firebase Hosting Alt", DomainPattern: "{keyword}.web.app", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Firebase RTDB", DomainPattern: "{keyword}.firebaseio.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Firebase Auth", DomainPattern: "{keyword}.firebaseapp.com/__/auth/handler", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Containers
-	{Provider: "GCP", ServiceType: "GKE", DomainPattern: "{keyword}.{region}.gke.io", Severity: "CRITICAL"},
-	{Provider: "GCP", ServiceType: "Container Registry", DomainPattern: "gcr.io/{keyword}", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Artifact Registry", DomainPattern: "{region}-docker.pkg.dev/{keyword}", Severity: "HIGH"},
+	{Provider: "GCP", ServiceType: "GKE", DomainPattern: "{keyword}.{region}.gke.io", Severity: "CRITICAL", Partition: "gcp", ServiceKey: "gke", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Container Registry", DomainPattern: "gcr.io/{keyword}", Severity: "HIGH", NameRule: acrNameRule},
+	{Provider: "GCP", ServiceType: "Artifact Registry", DomainPattern: "{region}-docker.pkg.dev/{keyword}", Severity: "HIGH", Partition: "gcp", ServiceKey: "artifact-registry", NameRule: genericNameRule},
 
 	// API & Integration
-	{Provider: "GCP", ServiceType: "API Gateway", DomainPattern: "{keyword}.apigateway.{project}.cloud.goog", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Endpoints", DomainPattern: "{keyword}.endpoints.{project}.cloud.goog", Severity: "HIGH"},
+	{Provider: "GCP", ServiceType: "API Gateway", DomainPattern: "{keyword}.apigateway.{project}.cloud.goog", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Endpoints", DomainPattern: "{keyword}.endpoints.{project}.cloud.goog", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Databases
-	{Provider: "GCP", ServiceType: "Cloud SQL", DomainPattern: "{region}:{keyword}.cloudsql.google.com", Severity: "CRITICAL"},
-	{Provider: "GCP", ServiceType: "Firestore", DomainPattern: "firestore.googleapis.com/projects/{keyword}", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "BigQuery", DomainPattern: "bigquery.googleapis.com/bigquery/v2/projects/{keyword}", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Bigtable", DomainPattern: "{keyword}.bigtable.googleapis.com", Severity: "CRITICAL"},
-	{Provider: "GCP", ServiceType: "Spanner", DomainPattern: "spanner.googleapis.com/projects/{keyword}", Severity: "CRITICAL"},
-	{Provider: "GCP", ServiceType: "Memorystore", DomainPattern: "{keyword}.redis.{region}.gce.googleapis.com", Severity: "CRITICAL"},
+	{Provider: "GCP", ServiceType: "Cloud SQL", DomainPattern: "{region}:{keyword}.cloudsql.google.com", Severity: "CRITICAL", Partition: "gcp", ServiceKey: "cloud-sql", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Firestore", DomainPattern: "firestore.googleapis.com/projects/{keyword}", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "BigQuery", DomainPattern: "bigquery.googleapis.com/bigquery/v2/projects/{keyword}", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Bigtable", DomainPattern: "{keyword}.bigtable.googleapis.com", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Spanner", DomainPattern: "spanner.googleapis.com/projects/{keyword}", Severity: "CRITICAL", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Memorystore", DomainPattern: "{keyword}.redis.{region}.gce.googleapis.com", Severity: "CRITICAL", Partition: "gcp", ServiceKey: "memorystore", NameRule: genericNameRule},
 
 	// CDN & Networking
-	{Provider: "GCP", ServiceType: "Cloud CDN", DomainPattern: "{keyword}.storage.googleapis.com", Severity: "MEDIUM"},
+	{Provider: "GCP", ServiceType: "Cloud CDN", DomainPattern: "{keyword}.storage.googleapis.com", Severity: "MEDIUM", NameRule: gcsBucketNameRule},
 
 	// Pub/Sub & Messaging
-	{Provider: "GCP", ServiceType: "Pub/Sub", DomainPattern: "pubsub.googleapis.com/projects/{keyword}", Severity: "MEDIUM"},
+	{Provider: "GCP", ServiceType: "Pub/Sub", DomainPattern: "pubsub.googleapis.com/projects/{keyword}", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// ML & AI
-	{Provider: "GCP", ServiceType: "Vertex AI", DomainPattern: "{region}-aiplatform.googleapis.com/projects/{keyword}", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "AutoML", DomainPattern: "automl.googleapis.com/projects/{keyword}", Severity: "HIGH"},
+	{Provider: "GCP", ServiceType: "Vertex AI", DomainPattern: "{region}-aiplatform.googleapis.com/projects/{keyword}", Severity: "HIGH", Partition: "gcp", ServiceKey: "vertex-ai", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "AutoML", DomainPattern: "automl.googleapis.com/projects/{keyword}", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Source & CI/CD
-	{Provider: "GCP", ServiceType: "Cloud Source Repos", DomainPattern: "source.cloud.google.com/p/{keyword}", Severity: "HIGH"},
-	{Provider: "GCP", ServiceType: "Cloud Build", DomainPattern: "cloudbuild.googleapis.com/projects/{keyword}", Severity: "MEDIUM"},
+	{Provider: "GCP", ServiceType: "Cloud Source Repos", DomainPattern: "source.cloud.google.com/p/{keyword}", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "GCP", ServiceType: "Cloud Build", DomainPattern: "cloudbuild.googleapis.com/projects/{keyword}", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// ============== OTHER CLOUD PROVIDERS ==============
 	// DigitalOcean
-	{Provider: "DigitalOcean", ServiceType: "App Platform", DomainPattern: "{keyword}.ondigitalocean.app", Severity: "HIGH"},
-	{Provider: "DigitalOcean", ServiceType: "Spaces", DomainPattern: "{keyword}.digitaloceanspaces.com", Severity: "HIGH"},
-	{Provider: "DigitalOcean", ServiceType: "Spaces Region", DomainPattern: "{keyword}.{region}.digitaloceanspaces.com", Severity: "HIGH"},
+	{Provider: "DigitalOcean", ServiceType: "App Platform", DomainPattern: "{keyword}.ondigitalocean.app", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "DigitalOcean", ServiceType: "Spaces", DomainPattern: "{keyword}.digitaloceanspaces.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "DigitalOcean", ServiceType: "Spaces Region", DomainPattern: "{keyword}.{region}.digitaloceanspaces.com", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Heroku
-	{Provider: "Heroku", ServiceType: "App", DomainPattern: "{keyword}.herokuapp.com", Severity: "HIGH"},
+	{Provider: "Heroku", ServiceType: "App", DomainPattern: "{keyword}.herokuapp.com", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Alibaba Cloud
-	{Provider: "Alibaba", ServiceType: "OSS", DomainPattern: "{keyword}.oss-{region}.aliyuncs.com", Severity: "HIGH"},
-	{Provider: "Alibaba", ServiceType: "Function Compute", DomainPattern: "{keyword}.{region}.fc.aliyuncs.com", Severity: "HIGH"},
+	{Provider: "Alibaba", ServiceType: "OSS", DomainPattern: "{keyword}.oss-{region}.aliyuncs.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Alibaba", ServiceType: "Function Compute", DomainPattern: "{keyword}.{region}.fc.aliyuncs.com", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Oracle Cloud
-	{Provider: "Oracle", ServiceType: "Object Storage", DomainPattern: "{keyword}.objectstorage.{region}.oci.customer-oci.com", Severity: "HIGH"},
-	{Provider: "Oracle", ServiceType: "Functions", DomainPattern: "{keyword}.{region}.functions.oci.oraclecloud.com", Severity: "HIGH"},
+	{Provider: "Oracle", ServiceType: "Object Storage", DomainPattern: "{keyword}.objectstorage.{region}.oci.customer-oci.com", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "Oracle", ServiceType: "Functions", DomainPattern: "{keyword}.{region}.functions.oci.oraclecloud.com", Severity: "HIGH", NameRule: genericNameRule},
 
 	// IBM Cloud
-	{Provider: "IBM", ServiceType: "Cloud Object Storage", DomainPattern: "{keyword}.s3.{region}.cloud-object-storage.appdomain.cloud", Severity: "HIGH"},
-	{Provider: "IBM", ServiceType: "Code Engine", DomainPattern: "{keyword}.{region}.codeengine.appdomain.cloud", Severity: "HIGH"},
+	{Provider: "IBM", ServiceType: "Cloud Object Storage", DomainPattern: "{keyword}.s3.{region}.cloud-object-storage.appdomain.cloud", Severity: "HIGH", NameRule: genericNameRule},
+	{Provider: "IBM", ServiceType: "Code Engine", DomainPattern: "{keyword}.{region}.codeengine.appdomain.cloud", Severity: "HIGH", NameRule: genericNameRule},
 
 	// Netlify
-	{Provider: "Netlify", ServiceType: "Site", DomainPattern: "{keyword}.netlify.app", Severity: "MEDIUM"},
-	{Provider: "Netlify", ServiceType: "Functions", DomainPattern: "{keyword}.netlify.app/.netlify/functions/", Severity: "MEDIUM"},
+	{Provider: "Netlify", ServiceType: "Site", DomainPattern: "{keyword}.netlify.app", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Netlify", ServiceType: "Functions", DomainPattern: "{keyword}.netlify.app/.netlify/functions/", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Vercel
-	{Provider: "Vercel", ServiceType: "Deployment", DomainPattern: "{keyword}.vercel.app", Severity: "MEDIUM"},
+	{Provider: "Vercel", ServiceType: "Deployment", DomainPattern: "{keyword}.vercel.app", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Render
-	{Provider: "Render", ServiceType: "Web Service", DomainPattern: "{keyword}.onrender.com", Severity: "MEDIUM"},
+	{Provider: "Render", ServiceType: "Web Service", DomainPattern: "{keyword}.onrender.com", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Railway
-	{Provider: "Railway", ServiceType: "App", DomainPattern: "{keyword}.up.railway.app", Severity: "MEDIUM"},
+	{Provider: "Railway", ServiceType: "App", DomainPattern: "{keyword}.up.railway.app", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Fly.io
-	{Provider: "Fly", ServiceType: "App", DomainPattern: "{keyword}.fly.dev", Severity: "MEDIUM"},
+	{Provider: "Fly", ServiceType: "App", DomainPattern: "{keyword}.fly.dev", Severity: "MEDIUM", NameRule: genericNameRule},
 
 	// Cloudflare
-	{Provider: "Cloudflare", ServiceType: "Pages", DomainPattern: "{keyword}.pages.dev", Severity: "MEDIUM"},
-	{Provider: "Cloudflare", ServiceType: "Workers", DomainPattern: "{keyword}.workers.dev", Severity: "MEDIUM"},
-	{Provider: "Cloudflare", ServiceType: "R2", DomainPattern: "{keyword}.r2.dev", Severity: "HIGH"},
-}
-
-// AWSRegions for region-specific enumeration
-var AWSRegions = []string{
-	"us-east-1", "us-east-2", "us-west-1", "us-west-2",
-	"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-central-2",
-	"eu-north-1", "eu-south-1", "eu-south-2",
-	"ap-northeast-1", "ap-northeast-2", "ap-northeast-3",
-	"ap-southeast-1", "ap-southeast-2", "ap-southeast-3", "ap-southeast-4",
-	"ap-south-1", "ap-south-2", "ap-east-1",
-	"sa-east-1", "ca-central-1", "me-south-1", "me-central-1",
-	"af-south-1",
-}
-
-// AzureRegions for region-specific Azure enumeration
-var AzureRegions = []string{
-	"eastus", "eastus2", "westus", "westus2", "westus3",
-	"centralus", "northcentralus", "southcentralus", "westcentralus",
-	"canadacentral", "canadaeast",
-	"brazilsouth", "brazilsoutheast",
-	"northeurope", "westeurope", "uksouth", "ukwest",
-	"francecentral", "francesouth", "germanywestcentral",
-	"switzerlandnorth", "norwayeast", "swedencentral",
-	"uaenorth", "southafricanorth", "qatarcentral",
-	"australiaeast", "australiasoutheast", "australiacentral",
-	"eastasia", "southeastasia", "japaneast", "japanwest",
-	"koreacentral", "koreasouth", "centralindia", "westindia",
-}
-
-// GCPRegions for region-specific GCP enumeration
-var GCPRegions = []string{
-	"us-central1", "us-east1", "us-east4", "us-east5",
-	"us-west1", "us-west2", "us-west3", "us-west4", "us-south1",
-	"europe-west1", "europe-west2", "europe-west3", "europe-west4",
-	"europe-west6", "europe-west8", "europe-west9", "europe-west12",
-	"europe-north1", "europe-central2", "europe-southwest1",
-	"asia-east1", "asia-east2", "asia-northeast1", "asia-northeast2", "asia-northeast3",
-	"asia-south1", "asia-south2", "asia-southeast1", "asia-southeast2",
-	"australia-southeast1", "australia-southeast2",
-	"southamerica-east1", "southamerica-west1",
-	"northamerica-northeast1", "northamerica-northeast2",
-	"me-central1", "me-west1",
+	{Provider: "Cloudflare", ServiceType: "Pages", DomainPattern: "{keyword}.pages.dev", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Cloudflare", ServiceType: "Workers", DomainPattern: "{keyword}.workers.dev", Severity: "MEDIUM", NameRule: genericNameRule},
+	{Provider: "Cloudflare", ServiceType: "R2", DomainPattern: "{keyword}.r2.dev", Severity: "HIGH", NameRule: r2NameRule},
 }
 
 // CloudAssetEnumerator discovers all cloud assets for a given keyword
@@ -243,17 +239,35 @@ type CloudAssetEnumerator struct {
 	httpClient  *net.Client
 	dnsResolver *net.DNSResolver
 	config      *core.Config
+
+	// endpoints is the partition/region/hostname model Partition- and
+	// ServiceKey-tagged services in AllCloudServices resolve through.
+	// Loaded in Init from config.EndpointsPath, or the embedded default
+	// if that's empty.
+	endpoints *EndpointModel
+
+	// resolver renders the remaining (non-Partition/ServiceKey-tagged)
+	// services' hostnames - see endpoint_resolver.go.
+	resolver *EndpointResolver
 }
 
 func NewCloudAssetEnumerator(httpClient *net.Client, dnsResolver *net.DNSResolver) *CloudAssetEnumerator {
 	return &CloudAssetEnumerator{
 		httpClient:  httpClient,
 		dnsResolver: dnsResolver,
+		resolver:    defaultEndpointResolver(),
 	}
 }
 
-func (e *CloudAssetEnumerator) Init(config *core.Config) {
+func (e *CloudAssetEnumerator) Init(config *core.Config) error {
 	e.config = config
+
+	model, err := LoadEndpointModel(config.EndpointsPath)
+	if err != nil {
+		return fmt.Errorf("load endpoint model: %w", err)
+	}
+	e.endpoints = model
+	return nil
 }
 
 // GenerateAllTargets creates all possible cloud service URLs for a keyword
@@ -265,60 +279,133 @@ func (e *CloudAssetEnumerator) GenerateAllTargets(ctx context.Context, keyword s
 		default:
 		}
 
-		// Generate base target
-		url := strings.ReplaceAll(service.DomainPattern, "{keyword}", keyword)
-
-		// Handle region-specific services
-		if strings.Contains(url, "{region}") {
-			var regions []string
-			switch service.Provider {
-			case "AWS":
-				regions = AWSRegions
-			case "Azure":
-				regions = AzureRegions
-			case "GCP":
-				regions = GCPRegions
-			default:
-				regions = []string{"us-east-1"} // Default
+		if service.Partition != "" && service.ServiceKey != "" {
+			if service.NameRule.Match(keyword) {
+				e.generateModeledTargets(service, keyword, output)
 			}
+			e.generateModeledMutations(service, keyword, output)
+			continue
+		}
 
-			for _, region := range regions {
-				regionURL := strings.ReplaceAll(url, "{region}", region)
+		if service.NameRule.Match(keyword) {
+			// Region-specific services that aren't Partition/ServiceKey-
+			// tagged (DigitalOcean, Alibaba, Oracle, IBM, the un-modeled
+			// AWS/GCP oddballs, ...) resolve through e.resolver instead
+			// of a raw strings.ReplaceAll, so a Global service emits
+			// exactly one candidate and a RegionCoded one substitutes
+			// the right short code (see endpoint_resolver.go).
+			if strings.Contains(service.DomainPattern, "{region}") && !service.Global {
+				for _, region := range e.resolver.Regions(service) {
+					output <- CloudTarget{
+						URL:     fmt.Sprintf("https://%s", e.resolver.Resolve(service, region, keyword)),
+						Service: service,
+						Region:  region,
+					}
+				}
+			} else {
 				output <- CloudTarget{
-					URL:     fmt.Sprintf("https://%s", regionURL),
+					URL:     fmt.Sprintf("https://%s", e.resolver.Resolve(service, "", keyword)),
 					Service: service,
-					Region:  region,
 				}
 			}
-		} else if strings.Contains(url, "{project}") {
-			// GCP project-based services
-			projectURL := strings.ReplaceAll(url, "{project}", keyword)
-			output <- CloudTarget{
-				URL:     fmt.Sprintf("https://%s", projectURL),
-				Service: service,
-			}
-		} else {
-			output <- CloudTarget{
-				URL:     fmt.Sprintf("https://%s", url),
-				Service: service,
-			}
 		}
 
 		// Also apply mutations from config
 		for _, mut := range e.config.Mutations {
 			mutKeyword := keyword + "-" + mut
-			mutURL := strings.ReplaceAll(service.DomainPattern, "{keyword}", mutKeyword)
-			mutURL = strings.ReplaceAll(mutURL, "{region}", "us-east-1") // Default region for mutations
-			mutURL = strings.ReplaceAll(mutURL, "{project}", mutKeyword)
+			if !service.NameRule.Match(mutKeyword) {
+				continue
+			}
+
+			region := ""
+			if strings.Contains(service.DomainPattern, "{region}") && !service.Global {
+				regions := e.resolver.Regions(service)
+				if len(regions) > 0 {
+					region = regions[0]
+				}
+			}
 
 			output <- CloudTarget{
-				URL:     fmt.Sprintf("https://%s", mutURL),
+				URL:     fmt.Sprintf("https://%s", e.resolver.Resolve(service, region, mutKeyword)),
 				Service: service,
 			}
 		}
 	}
 }
 
+// generateModeledTargets emits one CloudTarget per region the service's
+// partition defines, with the hostname resolved via e.endpoints instead
+// of a hardcoded AWSRegions/AzureRegions/GCPRegions slice. A Global
+// service (e.g. IAM) has exactly one hostname regardless of region, so
+// it's resolved once against the partition's DefaultRegion rather than
+// multiplied across every region name (mirrors the e.resolver branch
+// above).
+func (e *CloudAssetEnumerator) generateModeledTargets(service CloudService, keyword string, output chan<- CloudTarget) {
+	partition, ok := e.endpoints.Partition(service.Partition)
+	if !ok {
+		return
+	}
+
+	// The endpoints.json model, not CloudService.Global, is the source
+	// of truth here: CloudService.Global only describes the legacy
+	// (non-modeled) hostname patterns below, and a Partition/ServiceKey
+	// service can be global without that field ever being set.
+	svc, ok := partition.Services[service.ServiceKey]
+	if !ok {
+		return
+	}
+
+	if svc.Global {
+		hostname, err := partition.ResolveEndpoint(service.ServiceKey, partition.DefaultRegion, keyword)
+		if err != nil {
+			return
+		}
+		output <- CloudTarget{
+			URL:     fmt.Sprintf("https://%s", hostname),
+			Service: service,
+		}
+		return
+	}
+
+	for _, region := range partition.RegionNames() {
+		hostname, err := partition.ResolveEndpoint(service.ServiceKey, region, keyword)
+		if err != nil {
+			continue
+		}
+		output <- CloudTarget{
+			URL:     fmt.Sprintf("https://%s", hostname),
+			Service: service,
+			Region:  region,
+		}
+	}
+}
+
+// generateModeledMutations applies config.Mutations to a modeled
+// service using its partition's own DefaultRegion, fixing the old bug
+// where every mutation got the hardcoded "us-east-1" region regardless
+// of provider.
+func (e *CloudAssetEnumerator) generateModeledMutations(service CloudService, keyword string, output chan<- CloudTarget) {
+	partition, ok := e.endpoints.Partition(service.Partition)
+	if !ok {
+		return
+	}
+
+	for _, mut := range e.config.Mutations {
+		mutKeyword := keyword + "-" + mut
+		if !service.NameRule.Match(mutKeyword) {
+			continue
+		}
+		hostname, err := partition.ResolveEndpoint(service.ServiceKey, partition.DefaultRegion, mutKeyword)
+		if err != nil {
+			continue
+		}
+		output <- CloudTarget{
+			URL:     fmt.Sprintf("https://%s", hostname),
+			Service: service,
+		}
+	}
+}
+
 // CloudTarget represents a target to check
 type CloudTarget struct {
 	URL     string
@@ -337,6 +424,12 @@ type CloudAssetResult struct {
 	Accessible  bool              `json:"accessible"`
 	Headers     map[string]string `json:"headers,omitempty"`
 	Error       string            `json:"error,omitempty"`
+
+	// Extra holds names actively enumerated from an accessible target
+	// beyond its bare status code - currently the file shares/queues/
+	// tables listAzureStorageSibling finds on an Azure File Share/Queue
+	// Storage/Table Storage account, keyed by discovery order.
+	Extra map[string]string `json:"extra,omitempty"`
 }
 
 // CheckTarget validates if a cloud target exists and is accessible
@@ -384,5 +477,19 @@ func (e *CloudAssetEnumerator) CheckTarget(ctx context.Context, target CloudTarg
 		result.Accessible = false
 	}
 
+	// File Share/Queue Storage/Table Storage expose anonymous listing
+	// APIs just like blob containers do, but - unlike blob, which
+	// AzureBlobProviderV2 probes directly - they're only ever reached
+	// through this generic status check. Actively enumerate them here
+	// rather than reporting just "reachable".
+	if result.Accessible && target.Service.Provider == "Azure" {
+		if names, ok := listAzureStorageSibling(e.httpClient, hostname, target.Service.ServiceType); ok {
+			result.Extra = make(map[string]string, len(names))
+			for i, name := range names {
+				result.Extra[fmt.Sprintf("%d", i)] = name
+			}
+		}
+	}
+
 	return result
 }
@@ -0,0 +1,102 @@
+package providers
+
+import "strings"
+
+// EndpointResolver renders a CloudService.DomainPattern for a given
+// region/keyword, for the single-pattern services in AllCloudServices
+// that aren't Partition/ServiceKey-tagged (see endpoints.go's
+// EndpointModel, which already handles that modeled case). It fixes
+// three things GenerateAllTargets's old raw strings.ReplaceAll got
+// wrong:
+//   - a Global service (CloudService.Global) gets exactly one
+//     candidate instead of one per entry in defaultRegionsByProvider
+//   - a service whose hostname in some region isn't a plain
+//     substitution into DomainPattern can say so via
+//     PerRegionOverrides
+//   - a service that substitutes a short region code instead of the
+//     region name itself (CloudService.RegionCoded, e.g. Cloud Run's
+//     "uc"/"ew"/"an") gets that substitution via RegionCodeMap
+//
+// This mirrors the AWS SDK's defaults.go service/region matrix, scaled
+// down to what AllCloudServices's non-modeled providers need.
+type EndpointResolver struct {
+	// PerRegionOverrides maps a CloudService.ServiceType to a hostname
+	// template used instead of DomainPattern for specific regions -
+	// the non-modeled equivalent of endpoints.go's Partition.Service
+	// Overrides, for services that aren't Partition/ServiceKey-tagged.
+	PerRegionOverrides map[string]map[string]string
+
+	// RegionCodeMap maps a region name (e.g. "us-central1") to the
+	// short code a RegionCoded service substitutes instead (e.g. "uc").
+	RegionCodeMap map[string]string
+}
+
+// defaultRegionsByProvider is the set of representative regions
+// GenerateAllTargets's non-modeled path enumerates a regional service
+// against. One entry per provider (rather than the old blanket
+// "us-east-1" for every provider) so a region-naming scheme other than
+// AWS's resolves to something its own provider actually uses.
+var defaultRegionsByProvider = map[string][]string{
+	"AWS":          {"us-east-1"},
+	"GCP":          {"us-central1"},
+	"DigitalOcean": {"nyc3"},
+	"Alibaba":      {"cn-hangzhou"},
+	"Oracle":       {"us-ashburn-1"},
+	"IBM":          {"us-south"},
+}
+
+// defaultEndpointResolver is the PerRegionOverrides/RegionCodeMap data
+// behind AllCloudServices's non-modeled regional oddballs.
+// PerRegionOverrides has no entries yet - every current non-modeled
+// regional service's hostname is a plain {region} substitution - but
+// stays available for the next one that isn't (mirrors
+// endpoints.go's Partition.Service.Overrides for the modeled case).
+func defaultEndpointResolver() *EndpointResolver {
+	return &EndpointResolver{
+		PerRegionOverrides: map[string]map[string]string{},
+		RegionCodeMap: map[string]string{
+			"us-central1":     "uc",
+			"europe-west1":    "ew",
+			"asia-northeast1": "an",
+		},
+	}
+}
+
+// Regions returns the representative regions GenerateAllTargets should
+// enumerate service against: none for a Global service (the caller
+// should resolve it once with region ""), otherwise
+// defaultRegionsByProvider[service.Provider].
+func (r *EndpointResolver) Regions(service CloudService) []string {
+	if service.Global {
+		return nil
+	}
+	return defaultRegionsByProvider[service.Provider]
+}
+
+// Resolve renders service's hostname for region and keyword: it
+// applies a PerRegionOverrides template if one exists for
+// service.ServiceType/region, substitutes a RegionCodeMap short code
+// for {region} when service.RegionCoded, then fills in
+// {keyword}/{project}/{region}.
+func (r *EndpointResolver) Resolve(service CloudService, region, keyword string) string {
+	pattern := service.DomainPattern
+	if overrides, ok := r.PerRegionOverrides[service.ServiceType]; ok {
+		if override, ok := overrides[region]; ok {
+			pattern = override
+		}
+	}
+
+	regionValue := region
+	if service.RegionCoded {
+		if code, ok := r.RegionCodeMap[region]; ok {
+			regionValue = code
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"{keyword}", keyword,
+		"{project}", keyword,
+		"{region}", regionValue,
+	)
+	return replacer.Replace(pattern)
+}
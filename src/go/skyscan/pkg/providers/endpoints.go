@@ -0,0 +1,177 @@
+package providers
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// templateReplacer builds the {keyword}/{project}/{region}/{dnsSuffix}
+// substitution used by ResolveEndpoint. The non-modeled hostname
+// patterns are rendered by EndpointResolver.Resolve, which builds its
+// own replacer.
+func templateReplacer(keyword, region, dnsSuffix string) *strings.Replacer {
+	return strings.NewReplacer(
+		"{keyword}", keyword,
+		"{project}", keyword,
+		"{region}", region,
+		"{dnsSuffix}", dnsSuffix,
+	)
+}
+
+// EndpointModel is a data-driven replacement for the source-baked
+// AWSRegions/AzureRegions/GCPRegions slices and the {region}-bearing
+// entries in AllCloudServices, modeled on the AWS SDK v3 endpoints.json
+// schema: one JSON document describing every partition (aws, aws-cn,
+// aws-us-gov, azure, azure-china, gcp), each carrying its own DNS
+// suffix, valid region list, and per-service hostname templates. Adding
+// a region or a sovereign partition is a JSON edit, not a recompile.
+type EndpointModel struct {
+	Partitions map[string]*Partition `json:"partitions"`
+}
+
+// Partition is one DNS/region namespace (a commercial cloud or one of
+// its sovereign variants).
+type Partition struct {
+	// DNSSuffix is the root domain services in this partition are
+	// hosted under, e.g. "amazonaws.com" or "amazonaws.com.cn".
+	// Available to hostname templates as "{dnsSuffix}".
+	DNSSuffix string `json:"dnsSuffix"`
+
+	// RegionRegex validates a region string before it's substituted
+	// into a hostname, so a typo'd or foreign-partition region fails
+	// fast instead of generating a target that can never resolve.
+	RegionRegex string `json:"regionRegex"`
+
+	// DefaultRegion is used when a service's hostname needs a region
+	// but the caller doesn't name one (e.g. CloudAssetEnumerator's
+	// keyword-mutation pass), replacing the old blanket "us-east-1"
+	// that was wrong for Azure and GCP targets.
+	DefaultRegion string `json:"defaultRegion"`
+
+	// Regions lists every region this partition enumerates. The value
+	// is reserved for future per-region metadata and is empty today.
+	Regions map[string]struct{} `json:"regions"`
+
+	// Services maps a service key (e.g. "rds", "cloud-functions") to
+	// its hostname template and any per-region overrides.
+	Services map[string]Service `json:"services"`
+
+	regionRe *regexp.Regexp
+}
+
+// Service describes one templated hostname, plus the handful of
+// regions whose hostname doesn't follow the template - AWS's own
+// endpoints file has the same wrinkle (s3.amazonaws.com in us-east-1
+// vs s3.{region}.amazonaws.com everywhere else).
+type Service struct {
+	// Hostname is rendered with "{keyword}", "{project}", "{region}",
+	// and "{dnsSuffix}" placeholders.
+	Hostname string `json:"hostname"`
+
+	// Global marks a service that has no regional hostname at all
+	// (e.g. IAM); ResolveEndpoint skips region validation for it.
+	Global bool `json:"global,omitempty"`
+
+	// Overrides replaces Hostname for specific regions that publish an
+	// irregular pattern.
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// DecodeModel parses an endpoints.json document. It mirrors the AWS
+// SDK's DecodeModel/DecodeModelOptions pair so a user-supplied
+// -endpoints file and the embedded default are loaded identically.
+func DecodeModel(r io.Reader) (*EndpointModel, error) {
+	var model EndpointModel
+	if err := json.NewDecoder(r).Decode(&model); err != nil {
+		return nil, fmt.Errorf("decode endpoint model: %w", err)
+	}
+	for id, p := range model.Partitions {
+		if p.RegionRegex == "" {
+			continue
+		}
+		re, err := regexp.Compile(p.RegionRegex)
+		if err != nil {
+			return nil, fmt.Errorf("partition %q: compile regionRegex: %w", id, err)
+		}
+		p.regionRe = re
+	}
+	return &model, nil
+}
+
+//go:embed endpoints.json
+var defaultEndpointsJSON []byte
+
+// DefaultEndpointModel decodes the endpoints.json embedded into the
+// skyscan binary - the same AWS/Azure/GCP region and hostname data that
+// used to live in AllCloudServices/AWSRegions/AzureRegions/GCPRegions,
+// now editable without a rebuild via -endpoints.
+func DefaultEndpointModel() (*EndpointModel, error) {
+	return DecodeModel(bytes.NewReader(defaultEndpointsJSON))
+}
+
+// LoadEndpointModel decodes path if set, otherwise falls back to
+// DefaultEndpointModel.
+func LoadEndpointModel(path string) (*EndpointModel, error) {
+	if path == "" {
+		return DefaultEndpointModel()
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open endpoints file: %w", err)
+	}
+	defer f.Close()
+	return DecodeModel(f)
+}
+
+// Partition looks up a partition by id ("aws", "azure-china", ...).
+func (m *EndpointModel) Partition(id string) (*Partition, bool) {
+	p, ok := m.Partitions[id]
+	return p, ok
+}
+
+// ValidRegion reports whether region matches p's RegionRegex. A
+// partition with no RegionRegex accepts any region.
+func (p *Partition) ValidRegion(region string) bool {
+	if p.regionRe == nil {
+		return true
+	}
+	return p.regionRe.MatchString(region)
+}
+
+// RegionNames returns p's regions as a slice, for callers (e.g.
+// registry.Provider) that need to range over them rather than look one
+// up by name. The order is not defined (it is map iteration order).
+func (p *Partition) RegionNames() []string {
+	names := make([]string, 0, len(p.Regions))
+	for name := range p.Regions {
+		names = append(names, name)
+	}
+	return names
+}
+
+// ResolveEndpoint renders service's hostname template for region and
+// keyword: it applies a per-region override if one exists, then
+// substitutes "{keyword}"/"{project}"/"{region}"/"{dnsSuffix}".
+func (p *Partition) ResolveEndpoint(service, region, keyword string) (string, error) {
+	svc, ok := p.Services[service]
+	if !ok {
+		return "", fmt.Errorf("unknown service %q in partition", service)
+	}
+	if !svc.Global && region != "" && !p.ValidRegion(region) {
+		return "", fmt.Errorf("region %q is not valid in this partition", region)
+	}
+
+	hostname := svc.Hostname
+	if override, ok := svc.Overrides[region]; ok {
+		hostname = override
+	}
+
+	replacer := templateReplacer(keyword, region, p.DNSSuffix)
+	return replacer.Replace(hostname), nil
+}
@@ -0,0 +1,101 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"skyscan/pkg/core"
+	"skyscan/pkg/net"
+)
+
+// FirebaseProvider probes Firebase Realtime Database instances for
+// anonymous read access — the GCP analogue of an open S3 bucket.
+type FirebaseProvider struct {
+	client *net.Client
+	config *core.Config
+}
+
+func NewFirebaseProvider(client *net.Client) *FirebaseProvider {
+	return &FirebaseProvider{
+		client: client,
+	}
+}
+
+func (p *FirebaseProvider) Name() string {
+	return "GCP-FIREBASE"
+}
+
+func (p *FirebaseProvider) Init(config *core.Config) error {
+	p.config = config
+	return nil
+}
+
+// Generate creates candidate RTDB root URLs for both the legacy and
+// default-database Firebase hostnames, applying config.Mutations the
+// same way S3Provider.Generate does.
+func (p *FirebaseProvider) Generate(ctx context.Context, keyword string, output chan<- string) {
+	endpoints := []string{
+		"https://%s.firebaseio.com/.json",
+		"https://%s-default-rtdb.firebaseio.com/.json",
+	}
+
+	// Base project
+	for _, ep := range endpoints {
+		select {
+		case <-ctx.Done():
+			return
+		case output <- fmt.Sprintf(ep, keyword):
+		}
+	}
+
+	// Mutations
+	for _, mut := range p.config.Mutations {
+		for _, ep := range endpoints {
+			// Suffix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf(ep, keyword+"-"+mut):
+			}
+
+			// Prefix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf(ep, mut+"-"+keyword):
+			}
+		}
+	}
+}
+
+// Check classifies a readable database as PUBLIC_READ and an
+// auth-gated one as PROTECTED; anything else is not a finding.
+func (p *FirebaseProvider) Check(ctx context.Context, target string) (*core.Result, error) {
+	status, size, err := p.client.Check(target)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case 200:
+		body, err := p.client.GetBody(target)
+		if err != nil || len(body) == 0 || string(body) == "null" {
+			return nil, nil
+		}
+		return &core.Result{
+			URL:         target,
+			Provider:    "GCP-FIREBASE",
+			Status:      status,
+			Size:        size,
+			Permissions: "PUBLIC_READ",
+		}, nil
+	case 401:
+		return &core.Result{
+			URL:         target,
+			Provider:    "GCP-FIREBASE",
+			Status:      status,
+			Permissions: "PROTECTED",
+		}, nil
+	default:
+		return nil, nil
+	}
+}
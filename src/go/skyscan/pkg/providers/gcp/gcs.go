@@ -0,0 +1,187 @@
+package gcp
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"skyscan/pkg/core"
+	"skyscan/pkg/net"
+	"strings"
+)
+
+// GCSProvider enumerates Google Cloud Storage buckets through the
+// S3-compatible XML API at storage.googleapis.com, the same surface
+// S3Provider targets on AWS, and classifies permissions from the
+// x-goog-acl header GCS returns instead of S3's AllUsers grant XML.
+type GCSProvider struct {
+	client *net.Client
+	config *core.Config
+}
+
+func NewGCSProvider(client *net.Client) *GCSProvider {
+	return &GCSProvider{
+		client: client,
+	}
+}
+
+func (p *GCSProvider) Name() string {
+	return "GCP-GCS"
+}
+
+func (p *GCSProvider) Init(config *core.Config) error {
+	p.config = config
+	return nil
+}
+
+// Generate creates candidate bucket URLs in both GCS addressing
+// styles, applying config.Mutations the same way S3Provider.Generate
+// does.
+func (p *GCSProvider) Generate(ctx context.Context, keyword string, output chan<- string) {
+	endpoints := []string{
+		"https://storage.googleapis.com/%s", // Path style
+		"https://%s.storage.googleapis.com", // Virtual-hosted style
+	}
+
+	// Base bucket
+	for _, ep := range endpoints {
+		select {
+		case <-ctx.Done():
+			return
+		case output <- fmt.Sprintf(ep, keyword):
+		}
+	}
+
+	// Mutations
+	for _, mut := range p.config.Mutations {
+		for _, ep := range endpoints {
+			// Suffix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf(ep, keyword+mut):
+			}
+
+			// Prefix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf(ep, mut+keyword):
+			}
+
+			// Separator
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf(ep, keyword+"-"+mut):
+			}
+		}
+	}
+}
+
+// Check validates a bucket URL, paging through the XML listing via
+// NextPageToken and classifying access from the x-goog-acl header.
+func (p *GCSProvider) Check(ctx context.Context, target string) (*core.Result, error) {
+	status, size, err := p.client.Check(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == 404 {
+		return nil, nil
+	}
+
+	var files []string
+	if status == 200 {
+		files = p.listGCSObjects(target)
+	}
+
+	return &core.Result{
+		URL:         target,
+		Provider:    "GCP",
+		Status:      status,
+		Size:        size,
+		Permissions: classifyGCSAccess(target, status),
+		Files:       files,
+	}, nil
+}
+
+// listGCSObjects pages through the XML bucket listing, following
+// NextPageToken until the server stops returning one or the result
+// hits the file cap.
+func (p *GCSProvider) listGCSObjects(target string) []string {
+	var files []string
+	pageToken := ""
+
+	for page := 0; page < 5 && len(files) < 50; page++ {
+		url := target
+		if pageToken != "" {
+			url = fmt.Sprintf("%s?page-token=%s", target, pageToken)
+		}
+
+		body, err := p.client.GetBody(url)
+		if err != nil {
+			break
+		}
+
+		var result gcsListBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			break
+		}
+
+		for _, item := range result.Contents {
+			files = append(files, fmt.Sprintf("%s (generation %s)", item.Key, item.Generation))
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	return files
+}
+
+// classifyGCSAccess inspects the x-goog-acl header GCS attaches to
+// bucket responses to tell a public (AllUsers) bucket apart from one
+// that's only readable by any authenticated Google account.
+func classifyGCSAccess(target string, fallbackStatus int) string {
+	resp, err := http.Head(target)
+	if err != nil {
+		return permsFromStatus(fallbackStatus)
+	}
+	defer resp.Body.Close()
+
+	acl := resp.Header.Get("x-goog-acl")
+	switch {
+	case strings.Contains(acl, "AllUsers"):
+		return "PUBLIC"
+	case strings.Contains(acl, "AllAuthenticatedUsers"):
+		return "AUTHENTICATED"
+	default:
+		return permsFromStatus(resp.StatusCode)
+	}
+}
+
+func permsFromStatus(status int) string {
+	switch status {
+	case 200:
+		return "PUBLIC"
+	case 403:
+		return "PROTECTED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// gcsListBucketResult is the XML schema GCS's S3-compatible XML API
+// returns. It matches S3's ListBucketResult but adds a Generation to
+// each object and paginates via NextPageToken instead of S3's
+// NextContinuationToken.
+type gcsListBucketResult struct {
+	Contents []struct {
+		Key        string `xml:"Key"`
+		Generation string `xml:"Generation"`
+	} `xml:"Contents"`
+	NextPageToken string `xml:"NextPageToken"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"skyscan/pkg/core"
 	"skyscan/pkg/net"
 	"strings"
@@ -160,13 +161,12 @@ func (p *GCSProviderV2) Check(ctx context.Context, target string) (*core.Result,
 	switch status {
 	case 200:
 		result.Permissions = "PUBLIC_METADATA"
-		// Try to list objects
-		listURL := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o", bucketName)
-		if body, err := p.httpClient.GetBody(listURL); err == nil {
-			result.Files = parseGCSJSON(body)
-			if len(result.Files) > 0 {
-				result.Permissions = "PUBLIC_LIST"
-			}
+		result.Files, result.Truncated = p.listObjects(bucketName)
+		if len(result.Files) > 0 {
+			result.Permissions = "PUBLIC_LIST"
+		}
+		if p.config.HarvestBucketConfig {
+			result.Severity = p.CheckBucketConfig(bucketName).Severity
 		}
 	case 401:
 		result.Permissions = "REQUIRES_AUTH"
@@ -213,6 +213,90 @@ func (p *GCSProviderV2) CheckPublicAccess(bucketName string) *GCSAccessResult {
 	return result
 }
 
+// CheckBucketConfig fetches iamConfiguration/website/logging/versioning/
+// encryption in a single JSON API call (GCS exposes bucket metadata as
+// one resource, unlike S3's per-sub-resource XML endpoints) and derives
+// a severity score, mirroring aws.S3ProviderV2.CheckWithACL.
+func (p *GCSProviderV2) CheckBucketConfig(bucketName string) *GCSBucketConfig {
+	result := &GCSBucketConfig{
+		BucketName: bucketName,
+	}
+
+	fields := "iamConfiguration,website,logging,versioning,encryption"
+	metaURL := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s?fields=%s", bucketName, fields)
+	body, err := p.httpClient.GetBody(metaURL)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	var meta gcsBucketMetadata
+	if err := json.Unmarshal(body, &meta); err == nil {
+		result.UniformBucketLevelAccess = meta.IAMConfiguration.UniformBucketLevelAccess.Enabled
+		result.PublicAccessPrevention = meta.IAMConfiguration.PublicAccessPrevention
+		result.WebsiteHostingEnabled = meta.Website != nil
+		result.LoggingDisabled = meta.Logging == nil
+		result.VersioningEnabled = meta.Versioning != nil && meta.Versioning.Enabled
+		result.DefaultKMSKeyDisabled = meta.Encryption == nil || meta.Encryption.DefaultKMSKeyName == ""
+	}
+
+	result.Severity = result.severity()
+	return result
+}
+
+// severity mirrors aws.S3ACLResult.severity: an open IAM policy
+// (caught separately by CheckPublicAccess) or explicitly disabled
+// public-access prevention is the worst case, then misconfigurations
+// like disabled logging/CMEK are a lesser but real finding.
+func (r *GCSBucketConfig) severity() string {
+	switch {
+	case r.PublicAccessPrevention == "inherited" && !r.UniformBucketLevelAccess:
+		return "HIGH"
+	case r.WebsiteHostingEnabled || r.LoggingDisabled || r.DefaultKMSKeyDisabled:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+// GCSBucketConfig holds the bucket-configuration analysis performed by
+// CheckBucketConfig.
+type GCSBucketConfig struct {
+	BucketName               string
+	UniformBucketLevelAccess bool
+	PublicAccessPrevention   string
+	WebsiteHostingEnabled    bool
+	LoggingDisabled          bool
+	VersioningEnabled        bool
+	DefaultKMSKeyDisabled    bool
+	Severity                 string
+	Error                    string
+}
+
+// gcsBucketMetadata is the subset of the JSON API's Bucket resource
+// CheckBucketConfig cares about.
+type gcsBucketMetadata struct {
+	IAMConfiguration struct {
+		UniformBucketLevelAccess struct {
+			Enabled bool `json:"enabled"`
+		} `json:"uniformBucketLevelAccess"`
+		PublicAccessPrevention string `json:"publicAccessPrevention"`
+	} `json:"iamConfiguration"`
+	Website *struct {
+		MainPageSuffix string `json:"mainPageSuffix"`
+		NotFoundPage   string `json:"notFoundPage"`
+	} `json:"website"`
+	Logging *struct {
+		LogBucket string `json:"logBucket"`
+	} `json:"logging"`
+	Versioning *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"versioning"`
+	Encryption *struct {
+		DefaultKMSKeyName string `json:"defaultKmsKeyName"`
+	} `json:"encryption"`
+}
+
 func extractGCSBucket(url string) string {
 	url = strings.TrimPrefix(url, "http://")
 	url = strings.TrimPrefix(url, "https://")
@@ -260,27 +344,68 @@ type GCSBinding struct {
 }
 
 type GCSObjectList struct {
-	Items []GCSObject `json:"items"`
+	Items         []GCSObject `json:"items"`
+	NextPageToken string      `json:"nextPageToken"`
 }
 
 type GCSObject struct {
-	Name    string `json:"name"`
-	Size    string `json:"size"`
-	Updated string `json:"updated"`
+	Name         string `json:"name"`
+	Size         string `json:"size"`
+	Updated      string `json:"updated"`
+	StorageClass string `json:"storageClass"`
 }
 
-func parseGCSJSON(body []byte) []string {
-	var result GCSObjectList
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil
+// listObjects follows the JSON API's pageToken pagination loop against
+// bucketName's object listing, accumulating object names up to
+// Config.MaxObjects/MaxPages. The returned bool reports whether more
+// objects existed beyond whichever cap was hit first.
+func (p *GCSProviderV2) listObjects(bucketName string) ([]string, bool) {
+	maxObjects := p.config.MaxObjects
+	if maxObjects <= 0 {
+		maxObjects = 1000
+	}
+	maxPages := p.config.MaxPages
+	if maxPages <= 0 {
+		maxPages = 10
 	}
 
 	var files []string
-	for i, obj := range result.Items {
-		if i >= 10 {
-			break
+	var token string
+	for page := 0; page < maxPages; page++ {
+		listURL := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o", bucketName)
+		if token != "" {
+			listURL += "?pageToken=" + url.QueryEscape(token)
 		}
-		files = append(files, fmt.Sprintf("%s (%s bytes)", obj.Name, obj.Size))
+
+		body, err := p.httpClient.GetBody(listURL)
+		if err != nil {
+			return files, false
+		}
+
+		var result GCSObjectList
+		if err := json.Unmarshal(body, &result); err != nil {
+			return files, false
+		}
+
+		for _, obj := range result.Items {
+			desc := fmt.Sprintf("%s (%s bytes", obj.Name, obj.Size)
+			if obj.Updated != "" {
+				desc += fmt.Sprintf(", modified %s", obj.Updated)
+			}
+			if obj.StorageClass != "" {
+				desc += fmt.Sprintf(", %s", obj.StorageClass)
+			}
+			files = append(files, desc+")")
+		}
+
+		if len(files) > maxObjects || (len(files) == maxObjects && result.NextPageToken != "") {
+			return files[:maxObjects], true
+		}
+		if result.NextPageToken == "" {
+			return files, false
+		}
+		token = result.NextPageToken
 	}
-	return files
+
+	return files, true // hit MaxPages with more pages still available
 }
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"skyscan/pkg/core"
 	"skyscan/pkg/net"
+	"skyscan/pkg/providers"
+	"strings"
 )
 
 type StorageProvider struct {
 	client *net.Client
 	config *core.Config
+
+	// endpointModel supplies the dnsSuffix for sovereign partitions
+	// (see partitionSuffixes), loaded in Init from config.EndpointsPath.
+	endpointModel *providers.EndpointModel
 }
 
 func NewStorageProvider(client *net.Client) *StorageProvider {
@@ -24,37 +30,71 @@ func (p *StorageProvider) Name() string {
 
 func (p *StorageProvider) Init(config *core.Config) error {
 	p.config = config
+
+	model, err := providers.LoadEndpointModel(config.EndpointsPath)
+	if err != nil {
+		return fmt.Errorf("load endpoint model: %w", err)
+	}
+	p.endpointModel = model
 	return nil
 }
 
-func (p *StorageProvider) Generate(ctx context.Context, keyword string, output chan<- string) {
-	// Base
-	select {
-	case <-ctx.Done():
-		return
-	case output <- fmt.Sprintf("https://storage.googleapis.com/%s", keyword):
-	}
+// partitionSuffixes returns the storage.googleapis.com-equivalent host
+// for "gcp" plus any other GCP-prefixed partition named in
+// Config.Partitions and present in p.endpointModel. Today the model
+// only defines the single commercial "gcp" partition, so this is a
+// near no-op - the hook exists so a future sovereign GCP partition
+// (e.g. a gov cloud variant) only needs an endpoints.json entry, not a
+// code change here.
+func (p *StorageProvider) partitionSuffixes() []string {
+	hosts := []string{"storage.googleapis.com"}
 
-	for _, mut := range p.config.Mutations {
-		// Suffix
-		select {
-		case <-ctx.Done():
-			return
-		case output <- fmt.Sprintf("https://storage.googleapis.com/%s%s", keyword, mut):
+	if p.config == nil || p.endpointModel == nil {
+		return hosts
+	}
+	for _, id := range p.config.Partitions {
+		if id == "" || id == "gcp" || !strings.HasPrefix(id, "gcp") {
+			continue
 		}
+		partition, ok := p.endpointModel.Partition(id)
+		if !ok {
+			continue
+		}
+		hosts = append(hosts, "storage."+partition.DNSSuffix)
+	}
+	return hosts
+}
 
-		// Prefix
+func (p *StorageProvider) Generate(ctx context.Context, keyword string, output chan<- string) {
+	for _, host := range p.partitionSuffixes() {
+		// Base
 		select {
 		case <-ctx.Done():
 			return
-		case output <- fmt.Sprintf("https://storage.googleapis.com/%s%s", mut, keyword):
+		case output <- fmt.Sprintf("https://%s/%s", host, keyword):
 		}
 
-		// Separator
-		select {
-		case <-ctx.Done():
-			return
-		case output <- fmt.Sprintf("https://storage.googleapis.com/%s-%s", keyword, mut):
+		for _, mut := range p.config.Mutations {
+			// Suffix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s/%s%s", host, keyword, mut):
+			}
+
+			// Prefix
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s/%s%s", host, mut, keyword):
+			}
+
+			// Separator
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s/%s-%s", host, keyword, mut):
+			}
 		}
 	}
 }
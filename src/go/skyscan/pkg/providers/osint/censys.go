@@ -0,0 +1,86 @@
+package osint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"skyscan/pkg/core"
+	"strings"
+	"time"
+)
+
+// CensysSource queries the Censys certificates search API for names
+// matching the keyword and emits any that look like cloud storage hosts.
+type CensysSource struct {
+	apiKey    string
+	apiSecret string
+}
+
+func NewCensysSource() *CensysSource {
+	return &CensysSource{}
+}
+
+func (s *CensysSource) Name() string {
+	return "CENSYS"
+}
+
+func (s *CensysSource) Init(config *core.Config) error {
+	if !config.OSINT.Censys.Enabled {
+		return fmt.Errorf("censys source disabled")
+	}
+	s.apiKey = config.OSINT.Censys.APIKey
+	s.apiSecret = config.OSINT.Censys.APISecret
+	return nil
+}
+
+type censysSearchResult struct {
+	Result struct {
+		Hits []struct {
+			Names []string `json:"names"`
+		} `json:"hits"`
+	} `json:"result"`
+}
+
+func (s *CensysSource) Stream(ctx context.Context, keyword string, output chan<- string) {
+	url := fmt.Sprintf("https://search.censys.io/api/v2/certificates/search?q=%s", keyword)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return
+	}
+	req.SetBasicAuth(s.apiKey, s.apiSecret)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var result censysSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	for _, hit := range result.Result.Hits {
+		for _, name := range hit.Names {
+			if !isCloudHostname(name) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s", name):
+			}
+		}
+	}
+}
+
+// isCloudHostname reports whether name looks like a cloud storage or CDN
+// host worth scanning, mirroring the filter CrtShProvider already applies.
+func isCloudHostname(name string) bool {
+	return strings.Contains(name, "s3.amazonaws.com") ||
+		strings.Contains(name, "blob.core.windows.net") ||
+		strings.Contains(name, "storage.googleapis.com")
+}
@@ -0,0 +1,91 @@
+package osint
+
+import (
+	"context"
+	"fmt"
+	"skyscan/pkg/core"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const certStreamURL = "wss://certstream.calidog.io"
+
+// CertStreamSource consumes the public CertStream firehose and emits a
+// candidate for every newly-issued certificate whose SAN contains the
+// keyword, turning a one-shot keyword scan into a continuous watch.
+// Unlike the other sources, Stream never returns on its own -- it runs
+// until ctx is cancelled, reconnecting on transport errors.
+type CertStreamSource struct{}
+
+func NewCertStreamSource() *CertStreamSource {
+	return &CertStreamSource{}
+}
+
+func (s *CertStreamSource) Name() string {
+	return "CERTSTREAM"
+}
+
+func (s *CertStreamSource) Init(config *core.Config) error {
+	if !config.OSINT.CertStream.Enabled {
+		return fmt.Errorf("certstream source disabled")
+	}
+	return nil
+}
+
+type certStreamMessage struct {
+	MessageType string `json:"message_type"`
+	Data        struct {
+		LeafCert struct {
+			AllDomains []string `json:"all_domains"`
+		} `json:"leaf_cert"`
+	} `json:"data"`
+}
+
+func (s *CertStreamSource) Stream(ctx context.Context, keyword string, output chan<- string) {
+	for ctx.Err() == nil {
+		s.consume(ctx, keyword, output)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+			// Reconnect after a transient disconnect.
+		}
+	}
+}
+
+func (s *CertStreamSource) consume(ctx context.Context, keyword string, output chan<- string) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, certStreamURL, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		var msg certStreamMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if msg.MessageType != "certificate_update" {
+			continue
+		}
+
+		for _, domain := range msg.Data.LeafCert.AllDomains {
+			if !strings.Contains(strings.ToLower(domain), strings.ToLower(keyword)) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s", domain):
+			}
+		}
+	}
+}
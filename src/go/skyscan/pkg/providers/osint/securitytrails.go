@@ -0,0 +1,68 @@
+package osint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"skyscan/pkg/core"
+	"time"
+)
+
+// SecurityTrailsSource queries SecurityTrails for subdomains of the
+// keyword (treated as a base domain) and emits every one it returns as
+// a candidate; it does not check what each subdomain resolves to.
+type SecurityTrailsSource struct {
+	apiKey string
+}
+
+func NewSecurityTrailsSource() *SecurityTrailsSource {
+	return &SecurityTrailsSource{}
+}
+
+func (s *SecurityTrailsSource) Name() string {
+	return "SECURITYTRAILS"
+}
+
+func (s *SecurityTrailsSource) Init(config *core.Config) error {
+	if !config.OSINT.SecurityTrails.Enabled {
+		return fmt.Errorf("securitytrails source disabled")
+	}
+	s.apiKey = config.OSINT.SecurityTrails.APIKey
+	return nil
+}
+
+type securityTrailsResponse struct {
+	Subdomains []string `json:"subdomains"`
+}
+
+func (s *SecurityTrailsSource) Stream(ctx context.Context, keyword string, output chan<- string) {
+	url := fmt.Sprintf("https://api.securitytrails.com/v1/domain/%s/subdomains", keyword)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("APIKEY", s.apiKey)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var result securityTrailsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	for _, sub := range result.Subdomains {
+		candidate := fmt.Sprintf("%s.%s", sub, keyword)
+		select {
+		case <-ctx.Done():
+			return
+		case output <- fmt.Sprintf("https://%s", candidate):
+		}
+	}
+}
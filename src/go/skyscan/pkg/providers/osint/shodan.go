@@ -0,0 +1,70 @@
+package osint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"skyscan/pkg/core"
+	"time"
+)
+
+// ShodanSource queries Shodan's host search for services advertising the
+// keyword in their TLS certificate data (an "ssl:" query; HTTP titles
+// are not searched) and emits their hostnames as candidates.
+type ShodanSource struct {
+	apiKey string
+}
+
+func NewShodanSource() *ShodanSource {
+	return &ShodanSource{}
+}
+
+func (s *ShodanSource) Name() string {
+	return "SHODAN"
+}
+
+func (s *ShodanSource) Init(config *core.Config) error {
+	if !config.OSINT.Shodan.Enabled {
+		return fmt.Errorf("shodan source disabled")
+	}
+	s.apiKey = config.OSINT.Shodan.APIKey
+	return nil
+}
+
+type shodanSearchResponse struct {
+	Matches []struct {
+		Hostnames []string `json:"hostnames"`
+	} `json:"matches"`
+}
+
+func (s *ShodanSource) Stream(ctx context.Context, keyword string, output chan<- string) {
+	url := fmt.Sprintf("https://api.shodan.io/shodan/host/search?key=%s&query=ssl:%s", s.apiKey, keyword)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var result shodanSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	for _, match := range result.Matches {
+		for _, host := range match.Hostnames {
+			select {
+			case <-ctx.Done():
+				return
+			case output <- fmt.Sprintf("https://%s", host):
+			}
+		}
+	}
+}
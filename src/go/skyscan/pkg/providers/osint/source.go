@@ -0,0 +1,19 @@
+package osint
+
+import (
+	"context"
+	"skyscan/pkg/core"
+)
+
+// Source is a pluggable passive-discovery feed. Unlike core.Provider,
+// a Source never issues validation checks of its own -- it only emits
+// candidate URLs discovered out-of-band (CT logs, passive DNS, search
+// engines) into the same output channel a Provider.Generate would use.
+type Source interface {
+	Name() string
+	Init(config *core.Config) error
+	// Stream emits candidate cloud URLs for keyword until ctx is done or
+	// the source is exhausted. Long-lived sources (e.g. CertStream) run
+	// until ctx.Done() and ignore keyword, filtering on it instead.
+	Stream(ctx context.Context, keyword string, output chan<- string)
+}
@@ -0,0 +1,217 @@
+// Package registry probes container-registry HTTP v2 APIs
+// (https://distribution.github.io/distribution/spec/api/) anonymously,
+// so a keyword match on AWS ECR, Azure ACR, GCP GCR/Artifact Registry,
+// GitHub GHCR, or Docker Hub surfaces as a first-class "container"
+// category finding instead of just DNS-level service detection.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"skyscan/pkg/core"
+	"skyscan/pkg/net"
+	"skyscan/pkg/providers"
+	"strings"
+)
+
+// Classification is the outcome of probing one registry's v2 API.
+const (
+	PublicCatalog = "PUBLIC_CATALOG" // /v2/_catalog is readable anonymously
+	PublicTags    = "PUBLIC_TAGS"    // /v2/<repo>/tags/list is readable, catalog is not
+	AuthRequired  = "AUTH_REQUIRED"  // /v2/ answers but demands credentials
+	NotFound      = "NOT_FOUND"      // no registry at this host/repo
+)
+
+// Target is one registry host + repo path to probe for a keyword.
+type Target struct {
+	Provider string // e.g. "AWS ECR", "Azure ACR"
+	Host     string // registry host, e.g. "acme.azurecr.io"
+	Repo     string // repository path probed via /v2/<repo>/tags/list
+	Region   string
+}
+
+func (t Target) baseURL() string {
+	return fmt.Sprintf("https://%s", t.Host)
+}
+
+// Provider probes container-registry v2 APIs for a keyword across the
+// major cloud and public registries.
+type Provider struct {
+	httpClient *net.Client
+	config     *core.Config
+
+	// endpoints supplies AWS/GCP region lists (see
+	// providers.EndpointModel), replacing the old hardcoded
+	// providers.AWSRegions/GCPRegions slices.
+	endpoints *providers.EndpointModel
+}
+
+func NewProvider(httpClient *net.Client) *Provider {
+	return &Provider{httpClient: httpClient}
+}
+
+func (p *Provider) Init(config *core.Config) error {
+	p.config = config
+
+	model, err := providers.LoadEndpointModel(config.EndpointsPath)
+	if err != nil {
+		return fmt.Errorf("load endpoint model: %w", err)
+	}
+	p.endpoints = model
+	return nil
+}
+
+// Targets enumerates the registries worth probing for keyword: AWS ECR
+// (one per region), Azure ACR, GCP GCR and Artifact Registry (one per
+// region), GitHub GHCR, and Docker Hub.
+func (p *Provider) Targets(keyword string) []Target {
+	var targets []Target
+
+	if aws, ok := p.endpoints.Partition("aws"); ok {
+		for _, region := range aws.RegionNames() {
+			targets = append(targets, Target{
+				Provider: "AWS ECR",
+				Host:     fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com", keyword, region),
+				Repo:     keyword,
+				Region:   region,
+			})
+		}
+	}
+
+	targets = append(targets, Target{
+		Provider: "Azure ACR",
+		Host:     fmt.Sprintf("%s.azurecr.io", keyword),
+		Repo:     keyword,
+	})
+
+	targets = append(targets, Target{
+		Provider: "GCP GCR",
+		Host:     "gcr.io",
+		Repo:     keyword,
+	})
+	if gcp, ok := p.endpoints.Partition("gcp"); ok {
+		for _, region := range gcp.RegionNames() {
+			targets = append(targets, Target{
+				Provider: "GCP Artifact Registry",
+				Host:     fmt.Sprintf("%s-docker.pkg.dev", region),
+				Repo:     keyword,
+				Region:   region,
+			})
+		}
+	}
+
+	targets = append(targets, Target{
+		Provider: "GitHub GHCR",
+		Host:     "ghcr.io",
+		Repo:     keyword,
+	})
+
+	targets = append(targets, Target{
+		Provider: "Docker Hub",
+		Host:     "registry-1.docker.io",
+		Repo:     keyword,
+	})
+
+	return targets
+}
+
+// catalogResponse is the body of a successful /v2/_catalog request.
+type catalogResponse struct {
+	Repositories []string `json:"repositories"`
+}
+
+// tagsResponse is the body of a successful /v2/<repo>/tags/list request.
+type tagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// Check issues GET /v2/, GET /v2/_catalog, and GET /v2/<repo>/tags/list
+// against target anonymously and classifies the result. It returns nil
+// (not an error) when the registry isn't present at all, mirroring how
+// the other providers treat a definitive "not found".
+func (p *Provider) Check(ctx context.Context, target Target) (*core.Result, error) {
+	base := target.baseURL()
+
+	status, _, err := p.httpClient.Check(base + "/v2/")
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, nil
+	}
+
+	result := &core.Result{
+		URL:      fmt.Sprintf("%s/v2/%s", base, target.Repo),
+		Provider: target.Provider,
+		Status:   status,
+	}
+
+	if status == 401 || status == 403 {
+		result.Permissions = AuthRequired
+		return result, nil
+	}
+
+	// The base endpoint answered anonymously (200). Try the catalog
+	// first since a public catalog is the more severe finding, then
+	// fall back to this repo's own tag list.
+	if catalogStatus, _, err := p.httpClient.Check(base + "/v2/_catalog"); err == nil && catalogStatus == 200 {
+		if body, err := p.httpClient.GetBody(base + "/v2/_catalog"); err == nil {
+			var catalog catalogResponse
+			if json.Unmarshal(body, &catalog) == nil && len(catalog.Repositories) > 0 {
+				result.Permissions = PublicCatalog
+				result.Files = catalog.Repositories
+				return result, nil
+			}
+		}
+	}
+
+	tagsURL := fmt.Sprintf("%s/v2/%s/tags/list", base, target.Repo)
+	if tagsStatus, _, err := p.httpClient.Check(tagsURL); err == nil && tagsStatus == 200 {
+		if body, err := p.httpClient.GetBody(tagsURL); err == nil {
+			var tags tagsResponse
+			if json.Unmarshal(body, &tags) == nil && len(tags.Tags) > 0 {
+				result.Permissions = PublicTags
+				result.Files = prefixTags(tags.Name, tags.Tags)
+				return result, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// prefixTags renders "<repo>:<tag>" entries so Files reads the same way
+// a `docker pull` reference would.
+func prefixTags(repo string, tags []string) []string {
+	files := make([]string, len(tags))
+	for i, tag := range tags {
+		files[i] = fmt.Sprintf("%s:%s", repo, tag)
+	}
+	return files
+}
+
+// Severity rates a registry finding: an anonymously-readable catalog on
+// a cloud-managed registry (ECR/ACR) exposes every image the account
+// owns, not just one repo, so it outranks a single exposed tag list.
+func Severity(provider, permissions string) string {
+	cloudManaged := strings.HasPrefix(provider, "AWS") || strings.HasPrefix(provider, "Azure")
+
+	switch permissions {
+	case PublicCatalog:
+		if cloudManaged {
+			return "CRITICAL"
+		}
+		return "HIGH"
+	case PublicTags:
+		if cloudManaged {
+			return "HIGH"
+		}
+		return "MEDIUM"
+	case AuthRequired:
+		return "LOW"
+	default:
+		return "LOW"
+	}
+}